@@ -16,7 +16,10 @@
 package backend
 
 import (
+	"context"
 	"crypto/sha256"
+	"errors"
+	"fmt"
 	"hash"
 
 	"github.com/consensys/gnark/constraint/solver"
@@ -60,6 +63,8 @@ type ProverConfig struct {
 	ChallengeHash  hash.Hash
 	KZGFoldingHash hash.Hash
 	Accelerator    string
+	Ctx            context.Context
+	Progress       ProgressFunc
 }
 
 // NewProverConfig returns a default ProverConfig with given prover options opts
@@ -70,6 +75,7 @@ func NewProverConfig(opts ...ProverOption) (ProverConfig, error) {
 		// separation tags for PLONK and Groth16
 		ChallengeHash:  sha256.New(),
 		KZGFoldingHash: sha256.New(),
+		Ctx:            context.Background(),
 	}
 	for _, option := range opts {
 		if err := option(&opt); err != nil {
@@ -101,7 +107,10 @@ func WithProverHashToFieldFunction(hFunc hash.Hash) ProverOption {
 // WithProverChallengeHashFunction sets the hash function used for computing
 // non-interactive challenges in Fiat-Shamir heuristic. If not set then by
 // default SHA2-256 is used. Used mainly for compatibility between different
-// systems and efficient recursion.
+// systems and efficient recursion. SNARK-friendly challenge hash functions based
+// on MiMC and Poseidon2 are provided by
+// [github.com/consensys/gnark/std/recursion.NewShort] and the options returned
+// by [github.com/consensys/gnark/std/recursion/plonk.GetNativeProverOptions].
 func WithProverChallengeHashFunction(hFunc hash.Hash) ProverOption {
 	return func(pc *ProverConfig) error {
 		pc.ChallengeHash = hFunc
@@ -133,6 +142,77 @@ func WithIcicleAcceleration() ProverOption {
 	}
 }
 
+// WithContext sets the context of the prover. When the context is cancelled,
+// the prover returns the context error without starting the next phase, FFT
+// batch or multi-scalar multiplication. The computations already running,
+// which can't be interrupted, complete in the background. If not set, then
+// [context.Background] is used.
+func WithContext(ctx context.Context) ProverOption {
+	return func(pc *ProverConfig) error {
+		if ctx == nil {
+			return errors.New("nil context")
+		}
+		pc.Ctx = ctx
+		return nil
+	}
+}
+
+// WithProgress sets the callback which is called when the prover starts and
+// finishes a phase. As the PLONK prover runs phases concurrently, the callback
+// may be called from several goroutines and a phase may be reported several
+// times.
+func WithProgress(fn ProgressFunc) ProverOption {
+	return func(pc *ProverConfig) error {
+		pc.Progress = fn
+		return nil
+	}
+}
+
+// ReportProgress calls the progress callback if it is set.
+func (pc *ProverConfig) ReportProgress(phase ProverPhase, done bool) {
+	if pc.Progress != nil {
+		pc.Progress(phase, done)
+	}
+}
+
+// ProverPhase identifies a phase of the prover for progress reporting.
+type ProverPhase uint8
+
+const (
+	// PhaseSolve is the solving of the constraint system.
+	PhaseSolve ProverPhase = iota
+	// PhaseMSM is a batch of multi-scalar multiplications.
+	PhaseMSM
+	// PhaseFFT is a batch of FFTs. In Groth16 it is the computation of the
+	// quotient. In PLONK it is the evaluation of the polynomials on each coset
+	// of the large domain and the interpolation of the quotient, reported
+	// several times during PhaseQuotient.
+	PhaseFFT
+	// PhaseQuotient is the computation of the PLONK quotient polynomial,
+	// including its FFTs.
+	PhaseQuotient
+)
+
+func (p ProverPhase) String() string {
+	switch p {
+	case PhaseSolve:
+		return "solve"
+	case PhaseMSM:
+		return "msm"
+	case PhaseFFT:
+		return "fft"
+	case PhaseQuotient:
+		return "quotient"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressFunc is a callback for reporting the progress of the prover. It is
+// called with done set to false when the phase starts and set to true when the
+// phase finishes.
+type ProgressFunc func(phase ProverPhase, done bool)
+
 // VerifierOption defines option for altering the behavior of the verifier. See
 // the descriptions of functions returning instances of this type for
 // implemented options.
@@ -176,7 +256,10 @@ func WithVerifierHashToFieldFunction(hFunc hash.Hash) VerifierOption {
 // WithVerifierChallengeHashFunction sets the hash function used for computing
 // non-interactive challenges in Fiat-Shamir heuristic. If not set then by
 // default SHA2-256 is used. Used mainly for compatibility between different
-// systems and efficient recursion.
+// systems and efficient recursion. SNARK-friendly challenge hash functions based
+// on MiMC and Poseidon2 are provided by
+// [github.com/consensys/gnark/std/recursion.NewShort] and the options returned
+// by [github.com/consensys/gnark/std/recursion/plonk.GetNativeVerifierOptions].
 func WithVerifierChallengeHashFunction(hFunc hash.Hash) VerifierOption {
 	return func(pc *VerifierConfig) error {
 		pc.ChallengeHash = hFunc
@@ -194,3 +277,14 @@ func WithVerifierKZGFoldingHashFunction(hFunc hash.Hash) VerifierOption {
 		return nil
 	}
 }
+
+// BatchVerificationError is returned by the batch verifiers when some of the
+// proofs are invalid.
+type BatchVerificationError struct {
+	// Invalid holds the indices of the invalid proofs in increasing order.
+	Invalid []int
+}
+
+func (e *BatchVerificationError) Error() string {
+	return fmt.Sprintf("invalid proofs at indices %v", e.Invalid)
+}
@@ -19,6 +19,7 @@ package groth16
 import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
 
+	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/pedersen"
 	"github.com/consensys/gnark-crypto/utils/unsafe"
 	"github.com/consensys/gnark/internal/utils"
@@ -535,3 +536,265 @@ func (pk *ProvingKey) ReadDump(r io.Reader) error {
 	return nil
 
 }
+
+// WriteTo writes binary encoding of the AggregatedProof elements to writer
+// points are stored in compressed form
+// use WriteRawTo(...) to encode the proof without point compression
+func (proof *AggregatedProof) WriteTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the AggregatedProof elements to writer
+// points are stored in uncompressed form
+// use WriteTo(...) to encode the proof with point compression
+func (proof *AggregatedProof) WriteRawTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, true)
+}
+
+// writeTo serialization format:
+// ComAB, ComC, ZAB, ZC, uint32(len(Rounds)), Rounds, FinalA, FinalB, FinalC,
+// FinalVKey, FinalWKey, VKeyOpening, WKeyOpening
+func (proof *AggregatedProof) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	toEncode := []interface{}{
+		(*gtEncoding)(&proof.ComAB[0]),
+		(*gtEncoding)(&proof.ComAB[1]),
+		(*gtEncoding)(&proof.ComC[0]),
+		(*gtEncoding)(&proof.ComC[1]),
+		(*gtEncoding)(&proof.ZAB),
+		&proof.ZC,
+		uint32(len(proof.Rounds)),
+	}
+	for i := range proof.Rounds {
+		toEncode = append(toEncode, proof.Rounds[i].elements()...)
+	}
+	toEncode = append(toEncode, proof.finalElements()...)
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode an AggregatedProof from reader
+// AggregatedProof must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (proof *AggregatedProof) ReadFrom(r io.Reader) (n int64, err error) {
+	dec := curve.NewDecoder(r)
+
+	var nbRounds uint32
+	toDecode := []interface{}{
+		(*gtEncoding)(&proof.ComAB[0]),
+		(*gtEncoding)(&proof.ComAB[1]),
+		(*gtEncoding)(&proof.ComC[0]),
+		(*gtEncoding)(&proof.ComC[1]),
+		(*gtEncoding)(&proof.ZAB),
+		&proof.ZC,
+		&nbRounds,
+	}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	if nbRounds > 64 {
+		return dec.BytesRead(), errors.New("invalid number of rounds")
+	}
+
+	proof.Rounds = make([]AggregationRound, nbRounds)
+	toDecode = toDecode[:0]
+	for i := range proof.Rounds {
+		toDecode = append(toDecode, proof.Rounds[i].elements()...)
+	}
+	toDecode = append(toDecode, proof.finalElements()...)
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+func (round *AggregationRound) elements() []interface{} {
+	return []interface{}{
+		(*gtEncoding)(&round.ComABL[0]),
+		(*gtEncoding)(&round.ComABL[1]),
+		(*gtEncoding)(&round.ComABR[0]),
+		(*gtEncoding)(&round.ComABR[1]),
+		(*gtEncoding)(&round.ZABL),
+		(*gtEncoding)(&round.ZABR),
+		(*gtEncoding)(&round.ComCL[0]),
+		(*gtEncoding)(&round.ComCL[1]),
+		(*gtEncoding)(&round.ComCR[0]),
+		(*gtEncoding)(&round.ComCR[1]),
+		&round.ZCL,
+		&round.ZCR,
+	}
+}
+
+func (proof *AggregatedProof) finalElements() []interface{} {
+	return []interface{}{
+		&proof.FinalA,
+		&proof.FinalB,
+		&proof.FinalC,
+		&proof.FinalVKey[0],
+		&proof.FinalVKey[1],
+		&proof.FinalWKey[0],
+		&proof.FinalWKey[1],
+		&proof.VKeyOpening[0],
+		&proof.VKeyOpening[1],
+		&proof.WKeyOpening[0],
+		&proof.WKeyOpening[1],
+	}
+}
+
+// gtEncoding wraps a GT element so that it can be passed to the curve
+// encoder and decoder. The subgroup membership of decoded elements is checked
+// by the verifier.
+type gtEncoding curve.GT
+
+func (g *gtEncoding) WriteTo(w io.Writer) (int64, error) {
+	b := (*curve.GT)(g).Bytes()
+	n, err := w.Write(b[:])
+	return int64(n), err
+}
+
+func (g *gtEncoding) ReadFrom(r io.Reader) (int64, error) {
+	var b [curve.SizeOfGT]byte
+	n, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return int64(n), err
+	}
+	return int64(n), (*curve.GT)(g).SetBytes(b[:])
+}
+
+// WriteTo writes binary encoding of the AggregationProvingKey to writer
+// points are compressed
+// use WriteRawTo(...) to encode the key without point compression
+func (pk *AggregationProvingKey) WriteTo(w io.Writer) (n int64, err error) {
+	return pk.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the AggregationProvingKey to writer
+// points are not compressed
+// use WriteTo(...) to encode the key with point compression
+func (pk *AggregationProvingKey) WriteRawTo(w io.Writer) (n int64, err error) {
+	return pk.writeTo(w, true)
+}
+
+func (pk *AggregationProvingKey) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	toEncode := []interface{}{
+		pk.G1.Alpha,
+		pk.G1.Beta,
+		pk.G2.Alpha,
+		pk.G2.Beta,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode an AggregationProvingKey from reader
+// AggregationProvingKey must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (pk *AggregationProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&pk.G1.Alpha,
+		&pk.G1.Beta,
+		&pk.G2.Alpha,
+		&pk.G2.Beta,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the AggregationVerifyingKey to writer
+// points are compressed
+// use WriteRawTo(...) to encode the key without point compression
+func (vk *AggregationVerifyingKey) WriteTo(w io.Writer) (n int64, err error) {
+	return vk.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the AggregationVerifyingKey to writer
+// points are not compressed
+// use WriteTo(...) to encode the key with point compression
+func (vk *AggregationVerifyingKey) WriteRawTo(w io.Writer) (n int64, err error) {
+	return vk.writeTo(w, true)
+}
+
+func (vk *AggregationVerifyingKey) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	toEncode := []interface{}{
+		&vk.G1.Generator,
+		&vk.G1.Alpha,
+		&vk.G1.Beta,
+		&vk.G2.Generator,
+		&vk.G2.Alpha,
+		&vk.G2.Beta,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode an AggregationVerifyingKey from reader
+// AggregationVerifyingKey must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (vk *AggregationVerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	dec := curve.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&vk.G1.Generator,
+		&vk.G1.Alpha,
+		&vk.G1.Beta,
+		&vk.G2.Generator,
+		&vk.G2.Alpha,
+		&vk.G2.Beta,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
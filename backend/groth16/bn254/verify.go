@@ -20,7 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/consensys/gnark/backend/solidity"
+	"hash"
 	"io"
+	"math/big"
+	"sort"
 	"text/template"
 	"time"
 
@@ -74,6 +77,215 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		close(chDone)
 	}()
 
+	kSumAff, folded, err := vk.sumPublicInputs(proof, publicWitness, opt.HashToFieldFn)
+	if err != nil {
+		return err
+	}
+	if err = vk.CommitmentKey.Verify(folded, proof.CommitmentPok); err != nil {
+		return err
+	}
+
+	// compute e(Σx.[Kvk(t)]1, -[γ]2)
+	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
+	if err != nil {
+		return err
+	}
+
+	// wait for (eKrsδ, eArBs)
+	if err := <-chDone; err != nil {
+		return err
+	}
+
+	right = curve.FinalExponentiation(&right, &doubleML)
+	if !vk.e.Equal(&right) {
+		return errPairingCheckFailed
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
+	return nil
+}
+
+// BatchVerify verifies the proofs with given VerifyingKey and public witnesses.
+//
+// The verification equations are combined with random scalars into a single
+// multi-pairing. If the combined check fails, the invalid proofs are identified
+// by bisection and a [backend.BatchVerificationError] listing them is returned.
+func BatchVerify(proofs []*Proof, vk *VerifyingKey, publicWitnesses []fr.Vector, opts ...backend.VerifierOption) error {
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new verifier config: %w", err)
+	}
+	if opt.HashToFieldFn == nil {
+		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
+	}
+	if len(proofs) != len(publicWitnesses) {
+		return fmt.Errorf("number of proofs %d and public witnesses %d mismatch", len(proofs), len(publicWitnesses))
+	}
+
+	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
+	for i := range publicWitnesses {
+		if len(publicWitnesses[i]) != nbPublicVars-1 {
+			return fmt.Errorf("invalid witness size for proof %d, got %d, expected %d (public - ONE_WIRE)", i, len(publicWitnesses[i]), len(vk.G1.K)-1)
+		}
+	}
+	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Int("nbProofs", len(proofs)).Logger()
+	start := time.Now()
+
+	// proofs with points outside of the correct subgroup are rejected directly,
+	// the other ones are checked together.
+	var invalid, toCheck []int
+	terms := make([]batchTerm, len(proofs))
+	for i, proof := range proofs {
+		if !proof.isValid() || !proof.CommitmentPok.IsInSubGroup() {
+			invalid = append(invalid, i)
+			continue
+		}
+		inSubGroup := true
+		for j := range proof.Commitments {
+			inSubGroup = inSubGroup && proof.Commitments[j].IsInSubGroup()
+		}
+		if !inSubGroup {
+			invalid = append(invalid, i)
+			continue
+		}
+		terms[i].kSum, terms[i].folded, err = vk.sumPublicInputs(proof, publicWitnesses[i], opt.HashToFieldFn)
+		if err != nil {
+			// malformed commitments invalidate the proof, not the whole batch
+			invalid = append(invalid, i)
+			continue
+		}
+		toCheck = append(toCheck, i)
+	}
+
+	bad, err := vk.bisect(proofs, terms, toCheck)
+	if err != nil {
+		return err
+	}
+	invalid = append(invalid, bad...)
+	if len(invalid) != 0 {
+		sort.Ints(invalid)
+		return &backend.BatchVerificationError{Invalid: invalid}
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("batch verifier done")
+	return nil
+}
+
+// batchTerm holds the per-proof values computed from the public witness.
+type batchTerm struct {
+	kSum, folded curve.G1Affine
+}
+
+// bisect returns the indices of the proofs failing verification. If the
+// combined check of all the proofs fails, the set is split in two and each half
+// is checked separately.
+func (vk *VerifyingKey) bisect(proofs []*Proof, terms []batchTerm, indices []int) ([]int, error) {
+	if len(indices) == 0 {
+		return nil, nil
+	}
+	ok, err := vk.batchCheck(proofs, terms, indices)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+	if len(indices) == 1 {
+		return indices, nil
+	}
+	left, err := vk.bisect(proofs, terms, indices[:len(indices)/2])
+	if err != nil {
+		return nil, err
+	}
+	right, err := vk.bisect(proofs, terms, indices[len(indices)/2:])
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+// batchCheck checks the random linear combination of the verification
+// equations of the proofs at the given indices:
+//
+//	∏ e(rᵢ·Arᵢ, Bsᵢ) · e(Σ rᵢ·Krsᵢ, -[δ]2) · e(Σ rᵢ·Kᵢ, -[γ]2) = e([α]1, [β]2)^{Σ rᵢ}
+//
+// where Kᵢ is the public input part of the i-th proof. If the verifying key
+// has commitments, the proofs of knowledge of the folded commitments are
+// combined in the same multi-pairing with independent random scalars sᵢ.
+func (vk *VerifyingKey) batchCheck(proofs []*Proof, terms []batchTerm, indices []int) (bool, error) {
+	n := len(indices)
+	withCommitments := len(vk.PublicAndCommitmentCommitted) > 0
+
+	r := make([]fr.Element, n)
+	s := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		if _, err := r[i].SetRandom(); err != nil {
+			return false, err
+		}
+		if withCommitments {
+			if _, err := s[i].SetRandom(); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	P := make([]curve.G1Affine, n, n+4)
+	Q := make([]curve.G2Affine, n, n+4)
+	krs := make([]curve.G1Affine, n)
+	kSums := make([]curve.G1Affine, n)
+	folded := make([]curve.G1Affine, n)
+	poks := make([]curve.G1Affine, n)
+	var rSum fr.Element
+	var bi big.Int
+	for i, idx := range indices {
+		r[i].BigInt(&bi)
+		P[i].ScalarMultiplication(&proofs[idx].Ar, &bi)
+		Q[i] = proofs[idx].Bs
+		krs[i] = proofs[idx].Krs
+		kSums[i] = terms[idx].kSum
+		folded[i] = terms[idx].folded
+		poks[i] = proofs[idx].CommitmentPok
+		rSum.Add(&rSum, &r[i])
+	}
+
+	var krsSum, kSum curve.G1Affine
+	if _, err := krsSum.MultiExp(krs, r, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	if _, err := kSum.MultiExp(kSums, r, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	P = append(P, krsSum, kSum)
+	Q = append(Q, vk.G2.deltaNeg, vk.G2.gammaNeg)
+
+	if withCommitments {
+		var foldedSum, pokSum curve.G1Affine
+		if _, err := foldedSum.MultiExp(folded, s, ecc.MultiExpConfig{}); err != nil {
+			return false, err
+		}
+		if _, err := pokSum.MultiExp(poks, s, ecc.MultiExpConfig{}); err != nil {
+			return false, err
+		}
+		P = append(P, foldedSum, pokSum)
+		Q = append(Q, vk.CommitmentKey.G, vk.CommitmentKey.GRootSigmaNeg)
+	}
+
+	right, err := curve.Pair(P, Q)
+	if err != nil {
+		return false, err
+	}
+	var left curve.GT
+	left.Exp(vk.e, rSum.BigInt(&bi))
+	return left.Equal(&right), nil
+}
+
+// sumPublicInputs computes Σx.[Kvk(t)]1 for the public witness and the
+// commitments of the proof. It also returns the folded commitment for
+// checking the proof of knowledge.
+func (vk *VerifyingKey) sumPublicInputs(proof *Proof, publicWitness fr.Vector, hashToField hash.Hash) (kSumAff, folded curve.G1Affine, err error) {
+	if len(proof.Commitments) != len(vk.PublicAndCommitmentCommitted) {
+		return kSumAff, folded, fmt.Errorf("invalid number of commitments, got %d, expected %d", len(proof.Commitments), len(vk.PublicAndCommitmentCommitted))
+	}
 	maxNbPublicCommitted := 0
 	for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
 		maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
@@ -87,12 +299,12 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 			copy(commitmentPrehashSerialized[offset:], publicWitness[vk.PublicAndCommitmentCommitted[i][j]-1].Marshal())
 			offset += fr.Bytes
 		}
-		opt.HashToFieldFn.Write(commitmentPrehashSerialized[:offset])
-		hashBts := opt.HashToFieldFn.Sum(nil)
-		opt.HashToFieldFn.Reset()
+		hashToField.Write(commitmentPrehashSerialized[:offset])
+		hashBts := hashToField.Sum(nil)
+		hashToField.Reset()
 		nbBuf := fr.Bytes
-		if opt.HashToFieldFn.Size() < fr.Bytes {
-			nbBuf = opt.HashToFieldFn.Size()
+		if hashToField.Size() < fr.Bytes {
+			nbBuf = hashToField.Size()
 		}
 		var res fr.Element
 		res.SetBytes(hashBts[:nbBuf])
@@ -100,18 +312,15 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		copy(commitmentsSerialized[i*fr.Bytes:], res.Marshal())
 	}
 
-	if folded, err := pedersen.FoldCommitments(proof.Commitments, commitmentsSerialized); err != nil {
-		return err
-	} else {
-		if err = vk.CommitmentKey.Verify(folded, proof.CommitmentPok); err != nil {
-			return err
-		}
+	folded, err = pedersen.FoldCommitments(proof.Commitments, commitmentsSerialized)
+	if err != nil {
+		return kSumAff, folded, err
 	}
 
-	// compute e(Σx.[Kvk(t)]1, -[γ]2)
+	// compute Σx.[Kvk(t)]1
 	var kSum curve.G1Jac
 	if _, err := kSum.MultiExp(vk.G1.K[1:], publicWitness, ecc.MultiExpConfig{}); err != nil {
-		return err
+		return kSumAff, folded, err
 	}
 	kSum.AddMixed(&vk.G1.K[0])
 
@@ -119,26 +328,9 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 		kSum.AddMixed(&proof.Commitments[i])
 	}
 
-	var kSumAff curve.G1Affine
 	kSumAff.FromJacobian(&kSum)
 
-	right, err := curve.MillerLoop([]curve.G1Affine{kSumAff}, []curve.G2Affine{vk.G2.gammaNeg})
-	if err != nil {
-		return err
-	}
-
-	// wait for (eKrsδ, eArBs)
-	if err := <-chDone; err != nil {
-		return err
-	}
-
-	right = curve.FinalExponentiation(&right, &doubleML)
-	if !vk.e.Equal(&right) {
-		return errPairingCheckFailed
-	}
-
-	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
-	return nil
+	return kSumAff, folded, nil
 }
 
 // ExportSolidity writes a solidity Verifier contract on provided writer.
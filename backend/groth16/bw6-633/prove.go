@@ -109,10 +109,18 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		return nil
 	}))
 
+	if err := opt.Ctx.Err(); err != nil {
+		return nil, err
+	}
+	opt.ReportProgress(backend.PhaseSolve, false)
 	_solution, err := r1cs.Solve(fullWitness, solverOpts...)
 	if err != nil {
 		return nil, err
 	}
+	opt.ReportProgress(backend.PhaseSolve, true)
+	if err := opt.Ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	solution := _solution.(*cs.R1CSSolution)
 	wireValues := []fr.Element(solution.W)
@@ -132,10 +140,12 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	var h []fr.Element
 	chHDone := make(chan struct{}, 1)
 	go func() {
+		opt.ReportProgress(backend.PhaseFFT, false)
 		h = computeH(solution.A, solution.B, solution.C, &pk.Domain)
 		solution.A = nil
 		solution.B = nil
 		solution.C = nil
+		opt.ReportProgress(backend.PhaseFFT, true)
 		chHDone <- struct{}{}
 	}()
 
@@ -191,6 +201,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	chBs1Done := make(chan error, 1)
 	computeBS1 := func() {
 		<-chWireValuesB
+		if err := opt.Ctx.Err(); err != nil {
+			chBs1Done <- err
+			return
+		}
 		if _, err := bs1.MultiExp(pk.G1.B, wireValuesB, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
 			chBs1Done <- err
 			close(chBs1Done)
@@ -204,6 +218,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	chArDone := make(chan error, 1)
 	computeAR1 := func() {
 		<-chWireValuesA
+		if err := opt.Ctx.Err(); err != nil {
+			chArDone <- err
+			return
+		}
 		if _, err := ar.MultiExp(pk.G1.A, wireValuesA, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
 			chArDone <- err
 			close(chArDone)
@@ -224,6 +242,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		chKrs2Done := make(chan error, 1)
 		sizeH := int(pk.Domain.Cardinality - 1) // comes from the fact the deg(H)=(n-1)+(n-1)-n=n-2
 		go func() {
+			if err := opt.Ctx.Err(); err != nil {
+				chKrs2Done <- err
+				return
+			}
 			_, err := krs2.MultiExp(pk.G1.Z, h[:sizeH], ecc.MultiExpConfig{NbTasks: n / 2})
 			chKrs2Done <- err
 		}()
@@ -234,6 +256,10 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		toRemove = append(toRemove, commitmentInfo.CommitmentIndexes())
 		_wireValues := filterHeap(wireValues[r1cs.GetNbPublicVariables():], r1cs.GetNbPublicVariables(), internal.ConcatAll(toRemove...))
 
+		if err := opt.Ctx.Err(); err != nil {
+			chKrsDone <- err
+			return
+		}
 		if _, err := krs.MultiExp(pk.G1.K, _wireValues, ecc.MultiExpConfig{NbTasks: n / 2}); err != nil {
 			chKrsDone <- err
 			return
@@ -242,6 +268,9 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 		n := 3
 		for n != 0 {
 			select {
+			case <-opt.Ctx.Done():
+				chKrsDone <- opt.Ctx.Err()
+				return
 			case err := <-chKrs2Done:
 				if err != nil {
 					chKrsDone <- err
@@ -280,6 +309,9 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 			nbTasks *= 2
 		}
 		<-chWireValuesB
+		if err := opt.Ctx.Err(); err != nil {
+			return err
+		}
 		if _, err := Bs.MultiExp(pk.G2.B, wireValuesB, ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil {
 			return err
 		}
@@ -294,20 +326,36 @@ func Prove(r1cs *cs.R1CS, pk *ProvingKey, fullWitness witness.Witness, opts ...b
 	}
 
 	// wait for FFT to end, as it uses all our CPUs
-	<-chHDone
+	select {
+	case <-opt.Ctx.Done():
+		return nil, opt.Ctx.Err()
+	case <-chHDone:
+	}
 
 	// schedule our proof part computations
+	opt.ReportProgress(backend.PhaseMSM, false)
 	go computeKRS()
 	go computeAR1()
 	go computeBS1()
-	if err := computeBS2(); err != nil {
-		return nil, err
-	}
+	chBs2Done := make(chan error, 1)
+	go func() {
+		chBs2Done <- computeBS2()
+	}()
 
-	// wait for all parts of the proof to be computed.
-	if err := <-chKrsDone; err != nil {
-		return nil, err
+	// wait for all parts of the proof to be computed. A multi-exponentiation
+	// can't be interrupted: if the context is done, we return without waiting
+	// for the ones still running, the others are not started.
+	for _, chDone := range []chan error{chBs2Done, chKrsDone} {
+		select {
+		case <-opt.Ctx.Done():
+			return nil, opt.Ctx.Err()
+		case err := <-chDone:
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
+	opt.ReportProgress(backend.PhaseMSM, true)
 
 	log.Debug().Dur("took", time.Since(start)).Msg("prover done")
 
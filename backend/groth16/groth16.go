@@ -20,6 +20,7 @@
 package groth16
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -162,6 +163,81 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
+// BatchVerify verifies many proofs for the same VerifyingKey at once.
+//
+// The verification equations are combined with random scalars into a single
+// multi-pairing, which is much cheaper than calling [Verify] for every proof.
+// If some proofs are invalid, a [backend.BatchVerificationError] holding their
+// indices is returned.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitnesses []witness.Witness, opts ...backend.VerifierOption) error {
+	if len(proofs) != len(publicWitnesses) {
+		return fmt.Errorf("number of proofs %d and public witnesses %d mismatch", len(proofs), len(publicWitnesses))
+	}
+	switch _vk := vk.(type) {
+	case *groth16_bls12377.VerifyingKey:
+		p, w, err := castBatch[*groth16_bls12377.Proof, fr_bls12377.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bls12377.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bls12381.VerifyingKey:
+		p, w, err := castBatch[*groth16_bls12381.Proof, fr_bls12381.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bls12381.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bn254.VerifyingKey:
+		p, w, err := castBatch[*groth16_bn254.Proof, fr_bn254.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bn254.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bw6761.VerifyingKey:
+		p, w, err := castBatch[*groth16_bw6761.Proof, fr_bw6761.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bw6761.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bls24317.VerifyingKey:
+		p, w, err := castBatch[*groth16_bls24317.Proof, fr_bls24317.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bls24317.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bls24315.VerifyingKey:
+		p, w, err := castBatch[*groth16_bls24315.Proof, fr_bls24315.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bls24315.BatchVerify(p, _vk, w, opts...)
+	case *groth16_bw6633.VerifyingKey:
+		p, w, err := castBatch[*groth16_bw6633.Proof, fr_bw6633.Vector](proofs, publicWitnesses)
+		if err != nil {
+			return err
+		}
+		return groth16_bw6633.BatchVerify(p, _vk, w, opts...)
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// castBatch converts the proofs and public witnesses to their curve-typed
+// counterparts.
+func castBatch[P Proof, W any](proofs []Proof, publicWitnesses []witness.Witness) ([]P, []W, error) {
+	p := make([]P, len(proofs))
+	w := make([]W, len(publicWitnesses))
+	for i := range proofs {
+		var ok bool
+		if p[i], ok = proofs[i].(P); !ok {
+			return nil, nil, fmt.Errorf("proof %d: curve mismatch with verifying key", i)
+		}
+		if w[i], ok = publicWitnesses[i].Vector().(W); !ok {
+			return nil, nil, witness.ErrInvalidWitness
+		}
+	}
+	return p, w, nil
+}
+
 // Prove runs the groth16.Prove algorithm.
 //
 // if the force flag is set:
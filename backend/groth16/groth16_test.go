@@ -1,14 +1,18 @@
 package groth16_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -51,6 +55,96 @@ func TestCustomHashToField(t *testing.T) {
 	}
 }
 
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbProofs = 5
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &batchCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			proofs := make([]groth16.Proof, nbProofs)
+			publicWitnesses := make([]witness.Witness, nbProofs)
+			for i := 0; i < nbProofs; i++ {
+				w, err := frontend.NewWitness(&batchCircuit{X: i + 2, Y: (i + 2) * (i + 2)}, curve.ScalarField())
+				assert.NoError(err)
+				proofs[i], err = groth16.Prove(ccs, pk, w)
+				assert.NoError(err)
+				publicWitnesses[i], err = w.Public()
+				assert.NoError(err)
+			}
+			assert.NoError(groth16.BatchVerify(proofs, vk, publicWitnesses))
+
+			// swapping the public witnesses invalidates both proofs
+			publicWitnesses[1], publicWitnesses[3] = publicWitnesses[3], publicWitnesses[1]
+			err = groth16.BatchVerify(proofs, vk, publicWitnesses)
+			var batchErr *backend.BatchVerificationError
+			assert.True(errors.As(err, &batchErr))
+			assert.Equal([]int{1, 3}, batchErr.Invalid)
+			publicWitnesses[1], publicWitnesses[3] = publicWitnesses[3], publicWitnesses[1]
+
+			// proofs with a wrong number of commitments are reported, not the batch
+			commitments := reflect.ValueOf(proofs[2]).Elem().FieldByName("Commitments")
+			commitments.Set(reflect.Append(commitments, commitments.Index(0)))
+			reflect.ValueOf(proofs[4]).Elem().FieldByName("Commitments").SetLen(0)
+			assert.Error(groth16.Verify(proofs[2], vk, publicWitnesses[2]))
+			assert.Error(groth16.Verify(proofs[4], vk, publicWitnesses[4]))
+			err = groth16.BatchVerify(proofs, vk, publicWitnesses)
+			assert.True(errors.As(err, &batchErr))
+			assert.Equal([]int{2, 4}, batchErr.Invalid)
+		}, curve.String())
+	}
+}
+
+func TestProverContext(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &batchCircuit{X: 3, Y: 9}
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, &batchCircuit{})
+			assert.NoError(err)
+			pk, vk, err := groth16.Setup(ccs)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			assert.Run(func(assert *test.Assert) {
+				var phases []backend.ProverPhase
+				proof, err := groth16.Prove(ccs, pk, witness, backend.WithContext(context.Background()), backend.WithProgress(func(phase backend.ProverPhase, done bool) {
+					if done {
+						phases = append(phases, phase)
+					}
+				}))
+				assert.NoError(err)
+				pubWitness, err := witness.Public()
+				assert.NoError(err)
+				assert.NoError(groth16.Verify(proof, vk, pubWitness))
+				assert.Equal([]backend.ProverPhase{backend.PhaseSolve, backend.PhaseFFT, backend.PhaseMSM}, phases)
+			}, "progress")
+			assert.Run(func(assert *test.Assert) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				started := false
+				_, err := groth16.Prove(ccs, pk, witness, backend.WithContext(ctx), backend.WithProgress(func(backend.ProverPhase, bool) {
+					started = true
+				}))
+				assert.ErrorIs(err, context.Canceled)
+				assert.False(started, "the solver started with a cancelled context")
+			}, "cancelled")
+			assert.Run(func(assert *test.Assert) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				_, err := groth16.Prove(ccs, pk, witness, backend.WithContext(ctx), backend.WithProgress(func(phase backend.ProverPhase, done bool) {
+					if phase == backend.PhaseMSM && !done {
+						cancel()
+					}
+				}))
+				assert.ErrorIs(err, context.Canceled)
+			}, "cancelled-msm")
+		}, curve.String())
+	}
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
@@ -168,6 +262,21 @@ func (c *commitmentCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+type batchCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *batchCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	cmt, err := api.(frontend.Committer).Commit(c.X, c.Y)
+	if err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	api.AssertIsDifferent(cmt, 0)
+	return nil
+}
+
 type constantHash struct{}
 
 func (h constantHash) Write(p []byte) (n int, err error) { return len(p), nil }
@@ -124,7 +124,7 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	start := time.Now()
 
 	// init instance
-	g, ctx := errgroup.WithContext(context.Background())
+	g, ctx := errgroup.WithContext(opt.Ctx)
 	instance, err := newInstance(ctx, spr, pk, fullWitness, &opt)
 	if err != nil {
 		return nil, fmt.Errorf("new instance: %w", err)
@@ -158,6 +158,10 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	g.Go(instance.batchOpening)
 
 	if err := g.Wait(); err != nil {
+		if opt.Ctx.Err() != nil {
+			// the caller cancelled the proof, return the reason
+			return nil, opt.Ctx.Err()
+		}
 		return nil, err
 	}
 
@@ -322,10 +326,18 @@ func (s *instance) bsb22Hint(_ *big.Int, ins, outs []*big.Int) error {
 // solveConstraints computes the evaluation of the polynomials L, R, O
 // and sets x[id_L], x[id_R], x[id_O] in canonical form
 func (s *instance) solveConstraints() error {
+	if err := s.ctx.Err(); err != nil {
+		return errContextDone
+	}
+	s.opt.ReportProgress(backend.PhaseSolve, false)
 	_solution, err := s.spr.Solve(s.fullWitness, s.opt.SolverOpts...)
 	if err != nil {
 		return err
 	}
+	s.opt.ReportProgress(backend.PhaseSolve, true)
+	if err := s.ctx.Err(); err != nil {
+		return errContextDone
+	}
 	solution := _solution.(*cs.SparseR1CSSolution)
 	evaluationLDomainSmall := []fr.Element(solution.L)
 	evaluationRDomainSmall := []fr.Element(solution.R)
@@ -389,6 +401,9 @@ func (s *instance) commitToLRO() error {
 	case <-s.chbp:
 	}
 
+	s.opt.ReportProgress(backend.PhaseMSM, false)
+	defer s.opt.ReportProgress(backend.PhaseMSM, true)
+
 	g := new(errgroup.Group)
 
 	g.Go(func() (err error) {
@@ -524,18 +539,28 @@ func (s *instance) computeQuotient() (err error) {
 	s.x[id_LOne] = iop.NewPolynomial(&lone, iop.Form{Basis: iop.Lagrange, Layout: iop.Regular})
 	s.x[id_ZS] = s.x[id_Z].ShallowClone().Shift(1)
 
+	s.opt.ReportProgress(backend.PhaseQuotient, false)
 	numerator, err := s.computeNumerator()
 	if err != nil {
 		return err
 	}
 
+	s.opt.ReportProgress(backend.PhaseFFT, false)
 	s.h, err = divideByXMinusOne(numerator, [2]*fft.Domain{s.domain0, s.domain1})
 	if err != nil {
 		return err
 	}
+	s.opt.ReportProgress(backend.PhaseFFT, true)
+	s.opt.ReportProgress(backend.PhaseQuotient, true)
+	if err := s.ctx.Err(); err != nil {
+		return errContextDone
+	}
 
 	// commit to h
-	if err := commitToQuotient(s.h1(), s.h2(), s.h3(), s.proof, s.pk.Kzg); err != nil {
+	s.opt.ReportProgress(backend.PhaseMSM, false)
+	err = commitToQuotient(s.h1(), s.h2(), s.h3(), s.proof, s.pk.Kzg)
+	s.opt.ReportProgress(backend.PhaseMSM, true)
+	if err != nil {
 		return err
 	}
 
@@ -731,6 +756,9 @@ func (s *instance) batchOpening() error {
 	digestsToOpen[4] = s.pk.Vk.S[0]
 	digestsToOpen[5] = s.pk.Vk.S[1]
 
+	s.opt.ReportProgress(backend.PhaseMSM, false)
+	defer s.opt.ReportProgress(backend.PhaseMSM, true)
+
 	var err error
 	s.proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
 		polysToOpen,
@@ -891,6 +919,9 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 	mm := uint64(64 - bits.TrailingZeros64(m))
 
 	for i := 0; i < rho; i++ {
+		if err := s.ctx.Err(); err != nil {
+			return nil, errContextDone
+		}
 
 		coset.Mul(&coset, &shifters[i])
 		tmp.Exp(coset, bn).Sub(&tmp, &one)
@@ -921,6 +952,7 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 		// (Ql, Qr, Qm, Qo, S1, S2, S3, Qcp, Qc) and ID, LOne
 		// we could pre-compute theses rho*2 FFTs and store them
 		// at the cost of a huge memory footprint.
+		s.opt.ReportProgress(backend.PhaseFFT, false)
 		batchApply(s.x, func(p *iop.Polynomial) {
 			nbTasks := calculateNbTasks(len(s.x)-1) * 2
 			// shift polynomials to be in the correct coset
@@ -944,6 +976,7 @@ func (s *instance) computeNumerator() (*iop.Polynomial, error) {
 			// fft in the correct coset
 			p.ToLagrange(s.domain0, nbTasks).ToRegular()
 		})
+		s.opt.ReportProgress(backend.PhaseFFT, true)
 
 		wgBuf.Wait()
 		if _, err := iop.Evaluate(
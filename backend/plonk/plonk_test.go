@@ -2,8 +2,10 @@ package plonk_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 
 	"github.com/consensys/gnark"
@@ -194,6 +196,58 @@ func TestCustomKZGFoldingHash(t *testing.T) {
 	}
 }
 
+func TestProverContext(t *testing.T) {
+	assert := test.NewAssert(t)
+	assignment := &smallCircuit{X: 1}
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &smallCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+			witness, err := frontend.NewWitness(assignment, curve.ScalarField())
+			assert.NoError(err)
+			assert.Run(func(assert *test.Assert) {
+				var mu sync.Mutex
+				finished := make(map[backend.ProverPhase]bool)
+				proof, err := plonk.Prove(ccs, pk, witness, backend.WithContext(context.Background()), backend.WithProgress(func(phase backend.ProverPhase, done bool) {
+					mu.Lock()
+					defer mu.Unlock()
+					finished[phase] = finished[phase] || done
+				}))
+				assert.NoError(err)
+				pubWitness, err := witness.Public()
+				assert.NoError(err)
+				assert.NoError(plonk.Verify(proof, vk, pubWitness))
+				assert.True(finished[backend.PhaseSolve])
+				assert.True(finished[backend.PhaseMSM])
+				assert.True(finished[backend.PhaseQuotient])
+				assert.True(finished[backend.PhaseFFT])
+			}, "progress")
+			assert.Run(func(assert *test.Assert) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				_, err := plonk.Prove(ccs, pk, witness, backend.WithContext(ctx))
+				assert.ErrorIs(err, context.Canceled)
+			}, "cancelled")
+			assert.Run(func(assert *test.Assert) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				_, err := plonk.Prove(ccs, pk, witness, backend.WithContext(ctx), backend.WithProgress(func(phase backend.ProverPhase, done bool) {
+					if phase == backend.PhaseFFT && !done {
+						cancel()
+					}
+				}))
+				assert.ErrorIs(err, context.Canceled)
+			}, "cancelled-fft")
+		}, curve.String())
+	}
+}
+
 func BenchmarkSetup(b *testing.B) {
 	for _, curve := range getCurves() {
 		b.Run(curve.String(), func(b *testing.B) {
@@ -0,0 +1,95 @@
+// Command ccsdump prints the instructions of a serialized constraint system in
+// text form, one per line, with the blueprint, the constraint or hint call and
+// the source location when the circuit was compiled with the debug build tag.
+//
+// The constraint system is the output of ConstraintSystem.WriteTo:
+//
+//	ccsdump -curve bn254 -backend groth16 [-file circuit.go] [-func Define] circuit.r1cs
+//
+// The -file and -func flags take comma separated lists of patterns, an
+// instruction is printed if a frame of its call stack matches any of them.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/constraint"
+)
+
+var (
+	fCurve    = flag.String("curve", "bn254", "curve of the constraint system")
+	fBackend  = flag.String("backend", "groth16", "backend of the constraint system: groth16 (R1CS) or plonk (SparseR1CS)")
+	fFile     = flag.String("file", "", "comma separated source file patterns")
+	fFunction = flag.String("func", "", "comma separated function name patterns")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] [file]\n", os.Args[0])
+		fmt.Fprintln(flag.CommandLine.Output(), "reads the constraint system from stdin if no file is given")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	curve, err := ecc.IDFromString(*fCurve)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var ccs constraint.ConstraintSystem
+	switch *fBackend {
+	case "groth16":
+		ccs = groth16.NewCS(curve)
+	case "plonk":
+		ccs = plonk.NewCS(curve)
+	default:
+		log.Fatalf("unknown backend %q", *fBackend)
+	}
+
+	var r io.Reader = os.Stdin
+	switch flag.NArg() {
+	case 0:
+	case 1:
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if _, err := ccs.ReadFrom(bufio.NewReader(r)); err != nil {
+		log.Fatalf("reading constraint system: %v", err)
+	}
+
+	var opts []constraint.DumpOption
+	for _, pattern := range splitPatterns(*fFile) {
+		opts = append(opts, constraint.WithFileFilter(pattern))
+	}
+	for _, pattern := range splitPatterns(*fFunction) {
+		opts = append(opts, constraint.WithFunctionFilter(pattern))
+	}
+	if err := ccs.Dump(os.Stdout, opts...); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func splitPatterns(s string) []string {
+	var res []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
+}
@@ -136,6 +136,12 @@ func (cs *system) GetR1Cs() []constraint.R1C {
 	return toReturn
 }
 
+// Dump writes the instructions of the constraint system in text form.
+// See constraint.System.DumpInstructions for the format.
+func (cs *system) Dump(w io.Writer, opts ...constraint.DumpOption) error {
+	return cs.System.DumpInstructions(w, cs, opts...)
+}
+
 // GetNbCoefficients return the number of unique coefficients needed in the R1CS
 func (cs *system) GetNbCoefficients() int {
 	return len(cs.Coefficients)
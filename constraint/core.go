@@ -104,6 +104,9 @@ type System struct {
 	// maps constraint id to debugInfo id
 	// several constraints may point to the same debug info
 	MDebug map[int]int
+	// maps hint instruction id to debugInfo id of the hint call site
+	// only set when compiling with the debug build tag
+	MHintsDebug map[int]int
 
 	// maps hintID to hint string identifier
 	MHintsDependencies map[solver.HintID]string
@@ -135,6 +138,7 @@ func NewSystem(scalarField *big.Int, capacity int, t SystemType) System {
 		Type:               t,
 		SymbolTable:        debug.NewSymbolTable(),
 		MDebug:             map[int]int{},
+		MHintsDebug:        map[int]int{},
 		GnarkVersion:       gnark.Version.String(),
 		ScalarField:        scalarField.Text(16),
 		MHintsDependencies: make(map[solver.HintID]string),
@@ -297,6 +301,11 @@ func (system *System) AddSolverHint(f solver.Hint, id solver.HintID, input []Lin
 
 	system.AddInstruction(system.genericHint, *calldata)
 
+	if debug.Debug {
+		system.DebugInfo = append(system.DebugInfo, LogEntry(system.NewDebugInfo("hint", name)))
+		system.MHintsDebug[len(system.Instructions)-1] = len(system.DebugInfo) - 1
+	}
+
 	// return []uint32 to the pool
 	putBuffer(calldata)
 
@@ -426,11 +435,6 @@ func (cs *System) GetNbConstraints() int {
 	return cs.NbConstraints
 }
 
-func (cs *System) CheckUnconstrainedWires() error {
-	// TODO @gbotrel
-	return nil
-}
-
 func (cs *System) GetR1CIterator() R1CIterator {
 	return R1CIterator{cs: cs}
 }
@@ -0,0 +1,215 @@
+package constraint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark/debug"
+)
+
+// DumpOption filters the instructions written by [ConstraintSystem.Dump].
+type DumpOption func(*dumpConfig)
+
+type dumpConfig struct {
+	files, functions []string
+}
+
+// WithFileFilter keeps only the instructions emitted from a source file whose
+// path contains the given pattern. Several filters keep the instructions
+// matching any of them.
+func WithFileFilter(pattern string) DumpOption {
+	return func(cfg *dumpConfig) {
+		cfg.files = append(cfg.files, pattern)
+	}
+}
+
+// WithFunctionFilter keeps only the instructions emitted from a function whose
+// name contains the given pattern (for example "(*Circuit).Define"). Several
+// filters keep the instructions matching any of them.
+func WithFunctionFilter(pattern string) DumpOption {
+	return func(cfg *dumpConfig) {
+		cfg.functions = append(cfg.functions, pattern)
+	}
+}
+
+// DumpInstructions writes the instructions of the system in text form, one per
+// line, with tab separated columns:
+//
+//	instruction ID | blueprint | instruction | source location
+//
+// Constraints are printed with the variable names and the coefficients given by
+// the resolver, hints as outputs ← name(inputs). The source location is the
+// call stack recorded in the SymbolTable, innermost call first. It is only
+// available for the instructions which have debug information, that is, in
+// most cases, when the circuit is compiled with the debug build tag. When
+// filters are given, the instructions without source location are omitted.
+//
+// Most users should call [ConstraintSystem.Dump] instead.
+func (system *System) DumpInstructions(w io.Writer, r Resolver, opts ...DumpOption) error {
+	var cfg dumpConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	bw := bufio.NewWriter(w)
+	sbb := NewStringBuilder(r)
+	var (
+		r1c  R1C
+		sr1c SparseR1C
+		hint HintMapping
+	)
+	for iID, pi := range system.Instructions {
+		stack := system.instructionStack(iID, pi)
+		if !cfg.match(system, stack) {
+			continue
+		}
+		inst := pi.Unpack(system)
+		blueprint := system.Blueprints[pi.BlueprintID]
+
+		sbb.Reset()
+		sbb.WriteString(strconv.Itoa(iID))
+		sbb.WriteByte('\t')
+		sbb.WriteString(blueprintName(blueprint))
+		sbb.WriteByte('\t')
+		switch b := blueprint.(type) {
+		case BlueprintR1C:
+			b.DecompressR1C(&r1c, inst)
+			sbb.WriteString(r1c.String(r))
+		case BlueprintSparseR1C:
+			b.DecompressSparseR1C(&sr1c, inst)
+			sbb.WriteString(sr1c.String(r))
+		case BlueprintHint:
+			b.DecompressHint(&hint, inst)
+			for vID := hint.OutputRange.Start; vID < hint.OutputRange.End; vID++ {
+				if vID != hint.OutputRange.Start {
+					sbb.WriteString(", ")
+				}
+				sbb.WriteString(r.VariableToString(int(vID)))
+			}
+			sbb.WriteString(" ← ")
+			sbb.WriteString(system.MHintsDependencies[hint.HintID])
+			sbb.WriteByte('(')
+			for i, in := range hint.Inputs {
+				if i != 0 {
+					sbb.WriteString(", ")
+				}
+				sbb.WriteLinearExpression(in)
+			}
+			sbb.WriteByte(')')
+		default:
+			sbb.WriteString(fmt.Sprint(inst.Calldata))
+		}
+		sbb.WriteByte('\t')
+		for i, lID := range stack {
+			if i != 0 {
+				sbb.WriteString(" ← ")
+			}
+			location := system.SymbolTable.Locations[lID]
+			function := system.SymbolTable.Functions[location.FunctionID]
+			sbb.WriteString(function.Name)
+			sbb.WriteByte(' ')
+			sbb.WriteString(function.Filename)
+			sbb.WriteByte(':')
+			sbb.WriteString(strconv.Itoa(int(location.Line)))
+		}
+		sbb.WriteByte('\n')
+		if _, err := bw.WriteString(sbb.String()); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// instructionStack returns the call stack recorded for the instruction, if any.
+func (system *System) instructionStack(iID int, pi PackedInstruction) []int {
+	if dID, ok := system.MHintsDebug[iID]; ok {
+		return system.DebugInfo[dID].Stack
+	}
+	nbConstraints := system.Blueprints[pi.BlueprintID].NbConstraints()
+	for cID := int(pi.ConstraintOffset); cID < int(pi.ConstraintOffset)+nbConstraints; cID++ {
+		if dID, ok := system.MDebug[cID]; ok {
+			return system.DebugInfo[dID].Stack
+		}
+	}
+	return nil
+}
+
+// ConstraintStack returns the functions of the call stack recorded in the
+// SymbolTable for the constraint, innermost call first, or nil if the
+// constraint has no debug information.
+func (system *System) ConstraintStack(cID int) []debug.Function {
+	dID, ok := system.MDebug[cID]
+	if !ok {
+		return nil
+	}
+	stack := system.DebugInfo[dID].Stack
+	res := make([]debug.Function, len(stack))
+	for i, lID := range stack {
+		res[i] = system.SymbolTable.Functions[system.SymbolTable.Locations[lID].FunctionID]
+	}
+	return res
+}
+
+// ConstraintStrings returns the constraints of the system in text form, indexed
+// by constraint ID, with the variable names and the coefficients given by the
+// resolver.
+func (system *System) ConstraintStrings(r Resolver) []string {
+	res := make([]string, 0, system.GetNbConstraints())
+	if system.Type == SystemR1CS {
+		it := system.GetR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			res = append(res, c.String(r))
+		}
+	} else {
+		it := system.GetSparseR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			res = append(res, c.String(r))
+		}
+	}
+	return res
+}
+
+// BlueprintUsage returns the number of instructions of the system for each
+// blueprint, indexed by the name of the type implementing the blueprint.
+func (system *System) BlueprintUsage() map[string]int {
+	res := make(map[string]int)
+	for _, pi := range system.Instructions {
+		res[blueprintName(system.Blueprints[pi.BlueprintID])]++
+	}
+	return res
+}
+
+// match returns true if a frame of the stack matches one of the filters, or if
+// there are no filters.
+func (cfg *dumpConfig) match(system *System, stack []int) bool {
+	if len(cfg.files) == 0 && len(cfg.functions) == 0 {
+		return true
+	}
+	for _, lID := range stack {
+		function := system.SymbolTable.Functions[system.SymbolTable.Locations[lID].FunctionID]
+		for _, pattern := range cfg.files {
+			if strings.Contains(function.Filename, pattern) {
+				return true
+			}
+		}
+		for _, pattern := range cfg.functions {
+			if strings.Contains(function.Name, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// blueprintName returns the name of the type implementing the blueprint.
+func blueprintName(b Blueprint) string {
+	t := reflect.TypeOf(b)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,85 @@
+package constraint_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDump(t *testing.T) {
+	assert := require.New(t)
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), builder, &idHintCircuit{})
+		assert.NoError(err)
+
+		var buf bytes.Buffer
+		assert.NoError(ccs.Dump(&buf))
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		assert.Len(lines, ccs.GetNbInstructions())
+		assert.Contains(buf.String(), " ← github.com/consensys/gnark/constraint_test.idHint(")
+		assert.Contains(buf.String(), "\tBlueprintGenericHint\t")
+	}
+}
+
+func TestDumpFilter(t *testing.T) {
+	assert := require.New(t)
+
+	// build the system by hand to attach debug information regardless of the
+	// debug build tag.
+	r1cs := cs.NewR1CS(0)
+	r1cs.AddPublicVariable("1")
+	x := r1cs.AddSecretVariable("X")
+	y := r1cs.AddInternalVariable()
+	one := r1cs.One()
+	blueprint := r1cs.AddBlueprint(&constraint.BlueprintGenericR1C{})
+	square := constraint.R1C{
+		L: constraint.LinearExpression{r1cs.MakeTerm(one, x)},
+		R: constraint.LinearExpression{r1cs.MakeTerm(one, x)},
+		O: constraint.LinearExpression{r1cs.MakeTerm(one, y)},
+	}
+	cID := r1cs.AddR1C(square, blueprint)
+	r1cs.AttachDebugInfo(newDebugInfo(r1cs, "square"), []int{cID})
+	r1cs.AddR1C(constraint.R1C{
+		L: constraint.LinearExpression{r1cs.MakeTerm(one, y)},
+		R: constraint.LinearExpression{r1cs.MakeTerm(one, 0)},
+		O: constraint.LinearExpression{r1cs.MakeTerm(one, x)},
+	}, blueprint)
+
+	constraints := r1cs.ConstraintStrings(r1cs)
+	assert.Len(constraints, 2)
+	assert.Equal("X ⋅ X == v0", constraints[0])
+
+	var buf bytes.Buffer
+	assert.NoError(r1cs.Dump(&buf))
+	assert.Equal(2, strings.Count(buf.String(), "\n"))
+
+	for _, opt := range []constraint.DumpOption{
+		constraint.WithFileFilter("dump_test.go"),
+		constraint.WithFunctionFilter("TestDumpFilter"),
+	} {
+		buf.Reset()
+		assert.NoError(r1cs.Dump(&buf, opt))
+		assert.Equal(1, strings.Count(buf.String(), "\n"))
+		assert.True(strings.HasPrefix(buf.String(), "0\tBlueprintGenericR1C\tX ⋅ X == v0\t"), buf.String())
+		assert.Contains(buf.String(), "dump_test.go:")
+	}
+
+	buf.Reset()
+	assert.NoError(r1cs.Dump(&buf, constraint.WithFunctionFilter("Define")))
+	assert.Empty(buf.String())
+}
+
+// newDebugInfo mimics the frontend, where the debug information is created by a
+// helper of the builder. The call stack is collected from the caller of the
+// helper.
+func newDebugInfo(r1cs *cs.R1CS, name string) constraint.DebugInfo {
+	return r1cs.NewDebugInfo(name)
+}
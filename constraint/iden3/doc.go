@@ -0,0 +1,27 @@
+// Package iden3 converts rank-1 constraint systems and witnesses from and to
+// the binary formats defined by iden3 and used by circom and snarkjs.
+//
+// The .r1cs format stores the constraints of a rank-1 constraint system and the
+// .wtns format stores the values of all the wires of a solution:
+//
+//	file    ->  [magic | uint32(version) | uint32(nbSections) | section...]
+//	section ->  [uint32(type) | uint64(size) | content]
+//
+// All integers and field elements are encoded in little-endian, the field
+// elements on the smallest multiple of 8 bytes which fits the modulus.
+//
+// Both formats order the wires as gnark does: the constant wire 1, the public
+// inputs, the secret inputs and then the internal wires. The wire labels of the
+// .r1cs format are not used.
+//
+// A constraint system read with [ReadR1CS] doesn't know how to compute its
+// internal wires. They are computed by a single hint, which is provided by the
+// solver option returned by [ReadWitness] along with the inputs:
+//
+//	ccs, _ := iden3.ReadR1CS(r1csFile)
+//	fullWitness, wtnsOpt, _ := iden3.ReadWitness(wtnsFile, ccs)
+//	proof, _ := groth16.Prove(ccs, pk, fullWitness, backend.WithSolverOptions(wtnsOpt))
+//
+// See https://github.com/iden3/r1csfile/blob/master/doc/r1cs_bin_format.md for
+// the specification of the .r1cs format.
+package iden3
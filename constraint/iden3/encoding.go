@@ -0,0 +1,158 @@
+package iden3
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// elementSize returns the number of bytes used to encode the elements of the
+// field of given modulus.
+func elementSize(modulus *big.Int) int {
+	return (modulus.BitLen() + 63) / 64 * 8
+}
+
+// encoder writes the little-endian encoding of integers and field elements.
+type encoder struct {
+	bytes.Buffer
+	n8  int
+	buf []byte
+}
+
+func newEncoder(n8 int) *encoder {
+	return &encoder{n8: n8, buf: make([]byte, n8)}
+}
+
+func (e *encoder) writeUint32(v uint32) {
+	e.Write(binary.LittleEndian.AppendUint32(e.buf[:0], v))
+}
+
+func (e *encoder) writeUint64(v uint64) {
+	e.Write(binary.LittleEndian.AppendUint64(e.buf[:0], v))
+}
+
+// writeElement writes the canonical value of v, which must be reduced.
+func (e *encoder) writeElement(v *big.Int) {
+	v.FillBytes(e.buf)
+	for i, j := 0, len(e.buf)-1; i < j; i, j = i+1, j-1 {
+		e.buf[i], e.buf[j] = e.buf[j], e.buf[i]
+	}
+	e.Write(e.buf)
+}
+
+// decoder reads the little-endian encoding of integers and field elements. The
+// first error is recorded and the subsequent reads return zero values.
+type decoder struct {
+	data []byte
+	n8   int
+	err  error
+}
+
+var errShortSection = errors.New("unexpected end of section")
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = errShortSection
+		return nil
+	}
+	res := d.data[:n]
+	d.data = d.data[n:]
+	return res
+}
+
+func (d *decoder) readUint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) readUint64() uint64 {
+	if b := d.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (d *decoder) readElement() *big.Int {
+	b := d.next(d.n8)
+	if b == nil {
+		return new(big.Int)
+	}
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// writeFile writes the file header followed by the sections, in order.
+func writeFile(w io.Writer, magic string, version uint32, sections ...*encoder) error {
+	header := newEncoder(0)
+	header.WriteString(magic)
+	header.writeUint32(version)
+	header.writeUint32(uint32(len(sections)))
+	if _, err := header.WriteTo(w); err != nil {
+		return err
+	}
+	for i, s := range sections {
+		sectionHeader := newEncoder(0)
+		sectionHeader.writeUint32(uint32(i + 1))
+		sectionHeader.writeUint64(uint64(s.Len()))
+		if _, err := sectionHeader.WriteTo(w); err != nil {
+			return err
+		}
+		if _, err := s.WriteTo(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readFile reads the file header and returns the sections indexed by type.
+func readFile(r io.Reader, magic string, version uint32) (map[uint32][]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d := decoder{data: data}
+	if string(d.next(len(magic))) != magic {
+		return nil, fmt.Errorf("invalid file: expected %q magic", magic)
+	}
+	if v := d.readUint32(); d.err == nil && v != version {
+		return nil, fmt.Errorf("unsupported %s version %d, expected %d", magic, v, version)
+	}
+	nbSections := d.readUint32()
+	sections := make(map[uint32][]byte)
+	for i := uint32(0); i < nbSections && d.err == nil; i++ {
+		sectionType := d.readUint32()
+		size := d.readUint64()
+		if size > uint64(len(d.data)) {
+			return nil, fmt.Errorf("section %d: %w", sectionType, errShortSection)
+		}
+		if _, ok := sections[sectionType]; ok {
+			return nil, fmt.Errorf("duplicate section %d", sectionType)
+		}
+		sections[sectionType] = d.next(int(size))
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid %s header: %w", magic, d.err)
+	}
+	return sections, nil
+}
+
+// readFieldHeader reads the element size and the modulus which start the header
+// section of both formats.
+func (d *decoder) readFieldHeader() *big.Int {
+	d.n8 = int(d.readUint32())
+	if d.err == nil && (d.n8 == 0 || d.n8%8 != 0) {
+		d.err = fmt.Errorf("invalid field element size %d", d.n8)
+	}
+	return d.readElement()
+}
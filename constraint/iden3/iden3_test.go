@@ -0,0 +1,133 @@
+package iden3_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/iden3"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/stretchr/testify/require"
+)
+
+type circuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (c *circuit) Define(api frontend.API) error {
+	bits := api.ToBinary(c.X, 8)
+	s := api.Select(bits[2], api.Div(c.Y, c.X), api.Inverse(c.X))
+	api.AssertIsEqual(api.Mul(s, api.FromBinary(bits...)), c.Z)
+	return nil
+}
+
+func TestRoundTrip(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit{})
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&circuit{X: 4, Y: 7, Z: 7}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	var r1csFile, wtnsFile bytes.Buffer
+	assert.NoError(iden3.WriteR1CS(&r1csFile, ccs.(constraint.R1CS)))
+	assert.NoError(iden3.WriteWitness(&wtnsFile, ccs.(constraint.R1CS), fullWitness))
+
+	// header: magic, version, number of sections, then the type and size of
+	// the header section, the field element size and the modulus.
+	data := r1csFile.Bytes()
+	assert.Equal("r1cs", string(data[:4]))
+	assert.Equal(uint32(1), binary.LittleEndian.Uint32(data[4:8]))
+	assert.Equal(uint32(3), binary.LittleEndian.Uint32(data[8:12]))
+	assert.Equal(uint32(1), binary.LittleEndian.Uint32(data[12:16]))
+	assert.Equal(uint32(32), binary.LittleEndian.Uint32(data[24:28]))
+	assert.Equal("wtns", wtnsFile.String()[:4])
+
+	imported, err := iden3.ReadR1CS(bytes.NewReader(data))
+	assert.NoError(err)
+	assert.Equal(ccs.GetNbConstraints(), imported.GetNbConstraints())
+	assert.Equal(ccs.GetNbPublicVariables(), imported.GetNbPublicVariables())
+	assert.Equal(ccs.GetNbSecretVariables(), imported.GetNbSecretVariables())
+	assert.Equal(ccs.GetNbInternalVariables(), imported.GetNbInternalVariables())
+
+	var exported bytes.Buffer
+	assert.NoError(iden3.WriteR1CS(&exported, imported))
+	assert.Equal(data, exported.Bytes(), "export after import should be stable")
+
+	importedWitness, wtnsOpt, err := iden3.ReadWitness(bytes.NewReader(wtnsFile.Bytes()), imported)
+	assert.NoError(err)
+	assert.Equal(fullWitness.Vector(), importedWitness.Vector())
+
+	_, err = imported.Solve(importedWitness)
+	assert.Error(err, "solving an imported system requires the internal wires")
+
+	pk, vk, err := groth16.Setup(imported)
+	assert.NoError(err)
+	proof, err := groth16.Prove(imported, pk, importedWitness, backend.WithSolverOptions(wtnsOpt))
+	assert.NoError(err)
+	publicWitness, err := importedWitness.Public()
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+	// the internal wires must satisfy the constraints.
+	var invalid bytes.Buffer
+	assert.NoError(iden3.WriteWitness(&invalid, ccs.(constraint.R1CS), fullWitness))
+	b := invalid.Bytes()
+	b[len(b)-1] ^= 1
+	invalidWitness, invalidOpt, err := iden3.ReadWitness(bytes.NewReader(b), imported)
+	assert.NoError(err)
+	_, err = imported.Solve(invalidWitness, invalidOpt)
+	assert.Error(err)
+}
+
+// TestMultiplier2 imports the files of the circom circuit
+//
+//	template Multiplier2() {
+//		signal input a;
+//		signal input b;
+//		signal output c;
+//		c <== a*b;
+//	}
+//
+// with a = 3 and b = 11. The files are written from the r1csfile and wtns
+// specifications with the layout of circom: the constraint is -a ⋅ b = -c, the
+// wires are 1, c, a, b and c is a public output.
+func TestMultiplier2(t *testing.T) {
+	assert := require.New(t)
+
+	r1csData, err := os.ReadFile("testdata/multiplier2.r1cs")
+	assert.NoError(err)
+	wtnsData, err := os.ReadFile("testdata/multiplier2.wtns")
+	assert.NoError(err)
+
+	ccs, err := iden3.ReadR1CS(bytes.NewReader(r1csData))
+	assert.NoError(err)
+	assert.Equal(1, ccs.GetNbConstraints())
+	assert.Equal(2, ccs.GetNbPublicVariables())
+	assert.Equal(2, ccs.GetNbSecretVariables())
+	assert.Equal(0, ccs.GetNbInternalVariables())
+
+	fullWitness, wtnsOpt, err := iden3.ReadWitness(bytes.NewReader(wtnsData), ccs)
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	proof, err := groth16.Prove(ccs, pk, fullWitness, backend.WithSolverOptions(wtnsOpt))
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+	// the exported files have the same layout.
+	var r1csFile, wtnsFile bytes.Buffer
+	assert.NoError(iden3.WriteR1CS(&r1csFile, ccs, iden3.WithPublicOutputs(1)))
+	assert.Equal(r1csData, r1csFile.Bytes())
+	assert.NoError(iden3.WriteWitness(&wtnsFile, ccs, fullWitness, wtnsOpt))
+	assert.Equal(wtnsData, wtnsFile.Bytes())
+}
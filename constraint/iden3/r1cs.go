@@ -0,0 +1,215 @@
+package iden3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+	"github.com/consensys/gnark/constraint/solver"
+	cs_tinyfield "github.com/consensys/gnark/constraint/tinyfield"
+	"github.com/consensys/gnark/internal/tinyfield"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+const (
+	r1csMagic   = "r1cs"
+	r1csVersion = 1
+
+	r1csHeaderSection      = 1
+	r1csConstraintsSection = 2
+)
+
+// WriteOption configures [WriteR1CS].
+type WriteOption func(*writeConfig)
+
+type writeConfig struct {
+	nbPublicOutputs int
+}
+
+// WithPublicOutputs encodes the first n public inputs of the constraint system
+// as the public outputs of the .r1cs file. circom puts the outputs of the main
+// component first, so the constraint systems read with [ReadR1CS] are written
+// back identically with the number of public outputs of the original file.
+func WithPublicOutputs(n int) WriteOption {
+	return func(cfg *writeConfig) {
+		cfg.nbPublicOutputs = n
+	}
+}
+
+// WriteR1CS writes the constraint system in the iden3 .r1cs binary format.
+//
+// By default, all the public inputs are encoded as public inputs of the .r1cs
+// file, which has no public outputs, see [WithPublicOutputs]. Constraint
+// systems with commitments are not supported, as the format can't express them.
+func WriteR1CS(w io.Writer, r1cs constraint.R1CS, opts ...WriteOption) error {
+	if c := r1cs.GetCommitments(); c != nil && len(c.CommitmentIndexes()) != 0 {
+		return errors.New("constraint systems with commitments can't be exported to the .r1cs format")
+	}
+	var cfg writeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	field := r1cs.Field()
+	n8 := elementSize(field)
+	nbPublic, nbSecret := r1cs.GetNbPublicVariables(), r1cs.GetNbSecretVariables()
+	nbWires := nbPublic + nbSecret + r1cs.GetNbInternalVariables()
+	if cfg.nbPublicOutputs < 0 || cfg.nbPublicOutputs > nbPublic-1 {
+		return fmt.Errorf("invalid number of public outputs %d for %d public inputs", cfg.nbPublicOutputs, nbPublic-1)
+	}
+
+	header := newEncoder(n8)
+	header.writeUint32(uint32(n8))
+	header.writeElement(field)
+	header.writeUint32(uint32(nbWires))
+	header.writeUint32(uint32(cfg.nbPublicOutputs))
+	header.writeUint32(uint32(nbPublic - 1 - cfg.nbPublicOutputs))
+	header.writeUint32(uint32(nbSecret))
+	header.writeUint64(uint64(nbWires))
+
+	constraints := newEncoder(n8)
+	nbConstraints := 0
+	it := r1cs.GetR1CIterator()
+	for r1c := it.Next(); r1c != nil; r1c = it.Next() {
+		for _, l := range []constraint.LinearExpression{r1c.L, r1c.R, r1c.O} {
+			nbTerms := 0
+			for _, t := range l {
+				if t.CoeffID() != constraint.CoeffIdZero {
+					nbTerms++
+				}
+			}
+			constraints.writeUint32(uint32(nbTerms))
+			for _, t := range l {
+				if t.CoeffID() == constraint.CoeffIdZero {
+					continue
+				}
+				constraints.writeUint32(uint32(t.WireID()))
+				constraints.writeElement(r1cs.ToBigInt(r1cs.GetCoefficient(t.CoeffID())))
+			}
+		}
+		nbConstraints++
+	}
+	header.writeUint32(uint32(nbConstraints))
+
+	labels := newEncoder(n8)
+	for i := 0; i < nbWires; i++ {
+		labels.writeUint64(uint64(i))
+	}
+
+	return writeFile(w, r1csMagic, r1csVersion, header, constraints, labels)
+}
+
+// ReadR1CS reads a constraint system in the iden3 .r1cs binary format. The
+// field of the constraint system must be the scalar field of a curve supported
+// by gnark.
+//
+// The public outputs of the file are the first public inputs of the returned
+// constraint system. The internal wires are computed by a hint provided by the
+// solver option returned by [ReadWitness].
+func ReadR1CS(r io.Reader) (constraint.R1CS, error) {
+	sections, err := readFile(r, r1csMagic, r1csVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// header
+	d := decoder{data: sections[r1csHeaderSection]}
+	field := d.readFieldHeader()
+	nbWires := int(d.readUint32())
+	nbPublicOutputs := int(d.readUint32())
+	nbPublicInputs := int(d.readUint32())
+	nbSecret := int(d.readUint32())
+	d.readUint64() // labels
+	nbConstraints := int(d.readUint32())
+	if d.err != nil {
+		return nil, fmt.Errorf("invalid r1cs header: %w", d.err)
+	}
+	nbPublic := 1 + nbPublicOutputs + nbPublicInputs
+	nbInternal := nbWires - nbPublic - nbSecret
+	if nbInternal < 0 {
+		return nil, fmt.Errorf("invalid r1cs header: %d wires for %d inputs", nbWires, nbPublic+nbSecret)
+	}
+
+	// each constraint takes at least 12 bytes, bound the capacity accordingly.
+	r1cs, err := newR1CS(field, min(nbConstraints, len(sections[r1csConstraintsSection])/12))
+	if err != nil {
+		return nil, err
+	}
+	r1cs.AddPublicVariable("1")
+	for i := 1; i < nbPublic; i++ {
+		r1cs.AddPublicVariable(fmt.Sprintf("w%d", i))
+	}
+	for i := nbPublic; i < nbPublic+nbSecret; i++ {
+		r1cs.AddSecretVariable(fmt.Sprintf("w%d", i))
+	}
+	if nbInternal > 0 {
+		if _, err := r1cs.AddSolverHint(wtnsHint, solver.GetHintID(wtnsHint), nil, nbInternal); err != nil {
+			return nil, err
+		}
+	}
+
+	// constraints
+	blueprint := r1cs.(constraint.CustomizableSystem).AddBlueprint(&constraint.BlueprintGenericR1C{})
+	d = decoder{data: sections[r1csConstraintsSection], n8: d.n8}
+	for i := 0; i < nbConstraints; i++ {
+		var r1c constraint.R1C
+		for _, l := range []*constraint.LinearExpression{&r1c.L, &r1c.R, &r1c.O} {
+			nbTerms := int(d.readUint32())
+			if d.err != nil {
+				break
+			}
+			if nbTerms > len(d.data) {
+				return nil, fmt.Errorf("constraint %d: %w", i, errShortSection)
+			}
+			*l = make(constraint.LinearExpression, nbTerms)
+			for j := range *l {
+				wireID := int(d.readUint32())
+				coeff := d.readElement()
+				if d.err == nil && wireID >= nbWires {
+					return nil, fmt.Errorf("constraint %d: invalid wire %d", i, wireID)
+				}
+				(*l)[j] = r1cs.MakeTerm(r1cs.FromInterface(coeff), wireID)
+			}
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("constraint %d: %w", i, d.err)
+		}
+		r1cs.AddR1C(r1c, blueprint)
+	}
+
+	return r1cs, nil
+}
+
+// newR1CS returns an empty constraint system over the given field.
+func newR1CS(field *big.Int, capacity int) (constraint.R1CS, error) {
+	switch utils.FieldToCurve(field) {
+	case ecc.BLS12_377:
+		return cs_bls12377.NewR1CS(capacity), nil
+	case ecc.BLS12_381:
+		return cs_bls12381.NewR1CS(capacity), nil
+	case ecc.BN254:
+		return cs_bn254.NewR1CS(capacity), nil
+	case ecc.BW6_761:
+		return cs_bw6761.NewR1CS(capacity), nil
+	case ecc.BW6_633:
+		return cs_bw6633.NewR1CS(capacity), nil
+	case ecc.BLS24_315:
+		return cs_bls24315.NewR1CS(capacity), nil
+	case ecc.BLS24_317:
+		return cs_bls24317.NewR1CS(capacity), nil
+	default:
+		if field.Cmp(tinyfield.Modulus()) == 0 {
+			return cs_tinyfield.NewR1CS(capacity), nil
+		}
+		return nil, fmt.Errorf("unsupported field %s", field.String())
+	}
+}
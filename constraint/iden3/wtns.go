@@ -0,0 +1,163 @@
+package iden3
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+	"github.com/consensys/gnark/constraint/solver"
+	cs_tinyfield "github.com/consensys/gnark/constraint/tinyfield"
+)
+
+const (
+	wtnsMagic   = "wtns"
+	wtnsVersion = 2
+
+	wtnsHeaderSection = 1
+	wtnsValuesSection = 2
+)
+
+func init() {
+	solver.RegisterHint(wtnsHint)
+}
+
+// wtnsHint computes the internal wires of a constraint system read with
+// ReadR1CS. The registered function only reports the missing values, the actual
+// values are provided by the solver option returned by ReadWitness.
+func wtnsHint(_ *big.Int, _ []*big.Int, _ []*big.Int) error {
+	return errors.New("the internal wires of an imported constraint system must be provided by the solver option returned by iden3.ReadWitness")
+}
+
+// WriteWitness solves the constraint system with the given full witness and
+// writes the values of all the wires in the iden3 .wtns binary format.
+func WriteWitness(w io.Writer, r1cs constraint.R1CS, fullWitness witness.Witness, opts ...solver.Option) error {
+	solution, err := r1cs.Solve(fullWitness, opts...)
+	if err != nil {
+		return err
+	}
+	var values []*big.Int
+	switch s := solution.(type) {
+	case *cs_bls12377.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bls12381.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bls24315.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bls24317.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bn254.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bw6633.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_bw6761.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	case *cs_tinyfield.R1CSSolution:
+		values = vectorToBigInts(s.W)
+	default:
+		return fmt.Errorf("unexpected solution type %T", solution)
+	}
+
+	field := r1cs.Field()
+	n8 := elementSize(field)
+
+	header := newEncoder(n8)
+	header.writeUint32(uint32(n8))
+	header.writeElement(field)
+	header.writeUint32(uint32(len(values)))
+
+	wires := newEncoder(n8)
+	for _, v := range values {
+		wires.writeElement(v)
+	}
+
+	return writeFile(w, wtnsMagic, wtnsVersion, header, wires)
+}
+
+// ReadWitness reads the values of all the wires of the constraint system in the
+// iden3 .wtns binary format. It returns the full witness, holding the public and
+// secret inputs, and a solver option providing the internal wires of a
+// constraint system read with [ReadR1CS].
+func ReadWitness(r io.Reader, r1cs constraint.R1CS) (witness.Witness, solver.Option, error) {
+	sections, err := readFile(r, wtnsMagic, wtnsVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := decoder{data: sections[wtnsHeaderSection]}
+	field := d.readFieldHeader()
+	nbWires := int(d.readUint32())
+	if d.err != nil {
+		return nil, nil, fmt.Errorf("invalid wtns header: %w", d.err)
+	}
+	if field.Cmp(r1cs.Field()) != 0 {
+		return nil, nil, fmt.Errorf("witness field %s doesn't match the constraint system field %s", field.String(), r1cs.Field().String())
+	}
+	nbPublic, nbSecret := r1cs.GetNbPublicVariables(), r1cs.GetNbSecretVariables()
+	if expected := nbPublic + nbSecret + r1cs.GetNbInternalVariables(); nbWires != expected {
+		return nil, nil, fmt.Errorf("invalid witness size, got %d, expected %d", nbWires, expected)
+	}
+
+	d = decoder{data: sections[wtnsValuesSection], n8: d.n8}
+	if len(d.data) != nbWires*d.n8 {
+		return nil, nil, fmt.Errorf("invalid wtns values: %w", errShortSection)
+	}
+	values := make([]*big.Int, nbWires)
+	for i := range values {
+		values[i] = d.readElement()
+		if values[i].Cmp(field) >= 0 {
+			return nil, nil, fmt.Errorf("wire %d: value is not reduced", i)
+		}
+	}
+	if values[0].Cmp(big.NewInt(1)) != 0 {
+		return nil, nil, errors.New("the first wire must be 1")
+	}
+
+	// the witness doesn't contain the constant wire.
+	fullWitness, err := witness.New(field)
+	if err != nil {
+		return nil, nil, err
+	}
+	inputs := make(chan any, nbPublic+nbSecret-1)
+	for _, v := range values[1 : nbPublic+nbSecret] {
+		inputs <- v
+	}
+	close(inputs)
+	if err := fullWitness.Fill(nbPublic-1, nbSecret, inputs); err != nil {
+		return nil, nil, err
+	}
+
+	internal := values[nbPublic+nbSecret:]
+	opt := solver.OverrideHint(solver.GetHintID(wtnsHint), func(_ *big.Int, _ []*big.Int, outputs []*big.Int) error {
+		if len(outputs) != len(internal) {
+			return fmt.Errorf("expected %d internal wires, got %d", len(internal), len(outputs))
+		}
+		for i := range outputs {
+			outputs[i].Set(internal[i])
+		}
+		return nil
+	})
+
+	return fullWitness, opt, nil
+}
+
+// vectorToBigInts returns the canonical values of the field elements.
+func vectorToBigInts[E any, PE interface {
+	*E
+	BigInt(*big.Int) *big.Int
+}](v []E) []*big.Int {
+	res := make([]*big.Int, len(v))
+	for i := range v {
+		res[i] = PE(&v[i]).BigInt(new(big.Int))
+	}
+	return res
+}
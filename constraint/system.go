@@ -77,6 +77,10 @@ type ConstraintSystem interface {
 
 	GetInstruction(int) Instruction
 
+	// Dump writes the instructions of the constraint system in text form, one
+	// per line. See [System.DumpInstructions] for the format.
+	Dump(w io.Writer, opts ...DumpOption) error
+
 	GetCoefficient(i int) Element
 }
 
@@ -0,0 +1,231 @@
+package constraint
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnconstrainedWire describes a wire which is not uniquely determined by the
+// constraints of the system.
+type UnconstrainedWire struct {
+	// WireID is the ID of the wire in the system.
+	WireID int
+	// Name is the name of the wire as returned by VariableToString.
+	Name string
+	// Reason explains why the wire is not uniquely determined.
+	Reason string
+	// Hint is the name of the hint which outputs the wire. It is empty if the
+	// wire is not a hint output.
+	Hint string
+	// Stack is the call stack of the hint call site. It is only recorded when
+	// the circuit is compiled with the debug build tag.
+	Stack string
+}
+
+// UnconstrainedWiresError is returned by [System.CheckUnconstrainedWires] and
+// lists the wires which are not uniquely determined by the constraints.
+type UnconstrainedWiresError []UnconstrainedWire
+
+func (e UnconstrainedWiresError) Error() string {
+	var sbb strings.Builder
+	sbb.WriteString(strconv.Itoa(len(e)))
+	sbb.WriteString(" unconstrained wire(s):\n")
+	for _, w := range e {
+		sbb.WriteString(w.Name)
+		if w.Hint != "" {
+			sbb.WriteString(" (output of hint ")
+			sbb.WriteString(w.Hint)
+			sbb.WriteByte(')')
+		}
+		sbb.WriteString(": ")
+		sbb.WriteString(w.Reason)
+		sbb.WriteByte('\n')
+		if w.Stack != "" {
+			sbb.WriteString(w.Stack)
+		}
+	}
+	return sbb.String()
+}
+
+const (
+	reasonNoConstraint = "appears in no constraint"
+	reasonQuadratic    = "appears only in a constraint of degree 2 in the wire"
+	reasonShared       = "shares its only constraint with another free hint output"
+)
+
+// CheckUnconstrainedWires returns an [UnconstrainedWiresError] if the
+// constraint system has internal wires which are not uniquely determined by the
+// constraints. The analysis reports:
+//   - internal wires (including hint outputs) which appear in no constraint;
+//   - hint outputs which appear in a single constraint, where they are
+//     multiplied by themselves (for example x*x == y);
+//   - hint outputs which appear in a single constraint together with another
+//     such hint output (for example x + y == z).
+//
+// The analysis is syntactic, so it doesn't detect all the under-constrained
+// wires, but the reported ones are worth inspecting.
+func (system *System) CheckUnconstrainedWires() error {
+	nbInputs := system.GetNbPublicVariables() + system.GetNbSecretVariables()
+	nbInternal := system.NbInternalVariables
+
+	// hint outputs, indexed by internal wire ID, point to the hint instruction.
+	hintOf := make([]int, nbInternal)
+	for i := range hintOf {
+		hintOf[i] = -1
+	}
+	var hint HintMapping
+	for iID, pi := range system.Instructions {
+		b, ok := system.Blueprints[pi.BlueprintID].(BlueprintHint)
+		if !ok {
+			continue
+		}
+		b.DecompressHint(&hint, pi.Unpack(system))
+		for w := hint.OutputRange.Start; w < hint.OutputRange.End; w++ {
+			hintOf[int(w)-nbInputs] = iID
+		}
+	}
+
+	// the Groth16 commitment wires are determined by the proving system.
+	if c, ok := system.CommitmentInfo.(Groth16Commitments); ok {
+		for _, w := range c.CommitmentIndexes() {
+			if w >= nbInputs {
+				hintOf[w-nbInputs] = -1
+			}
+		}
+	}
+
+	// for every internal wire, we count the constraints it appears in and
+	// record the last one. We also record if the wire appears with degree 2 in
+	// a constraint.
+	count := make([]int, nbInternal)
+	last := make([]int, nbInternal)
+	inL := make([]int, nbInternal)
+	for i := range last {
+		last[i], inL[i] = -1, -1
+	}
+	quadratic := make([]bool, nbInternal)
+	cID := 0
+	visit := func(wire uint32, isQuadratic bool) {
+		w := int(wire) - nbInputs
+		if w < 0 || w >= nbInternal {
+			return
+		}
+		if last[w] != cID {
+			count[w]++
+			last[w] = cID
+			quadratic[w] = false
+		}
+		quadratic[w] = quadratic[w] || isQuadratic
+	}
+
+	switch system.Type {
+	case SystemR1CS:
+		it := system.GetR1CIterator()
+		for r1c := it.Next(); r1c != nil; r1c = it.Next() {
+			for _, t := range r1c.L {
+				if w := int(t.VID) - nbInputs; w >= 0 && t.CID != CoeffIdZero {
+					inL[w] = cID
+				}
+			}
+			for _, t := range r1c.R {
+				if w := int(t.VID) - nbInputs; w >= 0 && t.CID != CoeffIdZero {
+					visit(t.VID, inL[w] == cID)
+				}
+			}
+			for _, l := range []LinearExpression{r1c.L, r1c.O} {
+				for _, t := range l {
+					if t.CID != CoeffIdZero {
+						visit(t.VID, false)
+					}
+				}
+			}
+			cID++
+		}
+	case SystemSparseR1CS:
+		it := system.GetSparseR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			isQuadratic := c.QM != CoeffIdZero && c.XA == c.XB
+			if c.QL != CoeffIdZero || c.QM != CoeffIdZero {
+				visit(c.XA, isQuadratic)
+			}
+			if c.QR != CoeffIdZero || c.QM != CoeffIdZero {
+				visit(c.XB, isQuadratic)
+			}
+			if c.QO != CoeffIdZero {
+				visit(c.XC, false)
+			}
+			cID++
+		}
+	}
+
+	// hint outputs used in a single constraint are free in that constraint. If
+	// a constraint has several free wires, then they are not determined.
+	nbFree := make(map[int]int)
+	for w := range count {
+		if hintOf[w] != -1 && count[w] == 1 {
+			nbFree[last[w]]++
+		}
+	}
+
+	var res UnconstrainedWiresError
+	for w := range count {
+		var reason string
+		switch {
+		case count[w] == 0:
+			reason = reasonNoConstraint
+		case hintOf[w] == -1 || count[w] != 1:
+			continue
+		case quadratic[w]:
+			reason = reasonQuadratic
+		case nbFree[last[w]] > 1:
+			reason = reasonShared
+		default:
+			continue
+		}
+		res = append(res, system.newUnconstrainedWire(w+nbInputs, hintOf[w], reason))
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].WireID < res[j].WireID })
+	return res
+}
+
+func (system *System) newUnconstrainedWire(wireID, hintInstruction int, reason string) UnconstrainedWire {
+	w := UnconstrainedWire{
+		WireID: wireID,
+		Name:   system.VariableToString(wireID),
+		Reason: reason,
+	}
+	if hintInstruction == -1 {
+		return w
+	}
+	var hint HintMapping
+	b := system.Blueprints[system.Instructions[hintInstruction].BlueprintID].(BlueprintHint)
+	b.DecompressHint(&hint, system.GetInstruction(hintInstruction))
+	w.Hint = system.MHintsDependencies[hint.HintID]
+	if dID, ok := system.MHintsDebug[hintInstruction]; ok {
+		w.Stack = system.stackToString(system.DebugInfo[dID].Stack)
+	}
+	return w
+}
+
+// stackToString formats the stack of a debug info in the same way as the
+// solver does for unsatisfied constraints.
+func (system *System) stackToString(stack []int) string {
+	var sbb strings.Builder
+	for _, lID := range stack {
+		location := system.SymbolTable.Locations[lID]
+		function := system.SymbolTable.Functions[location.FunctionID]
+
+		sbb.WriteString(function.Name)
+		sbb.WriteByte('\n')
+		sbb.WriteByte('\t')
+		sbb.WriteString(function.Filename)
+		sbb.WriteByte(':')
+		sbb.WriteString(strconv.Itoa(int(location.Line)))
+		sbb.WriteByte('\n')
+	}
+	return sbb.String()
+}
@@ -0,0 +1,83 @@
+package constraint_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+type unusedHintCircuit struct {
+	X frontend.Variable
+}
+
+func (c *unusedHintCircuit) Define(api frontend.API) error {
+	_, err := api.Compiler().NewHint(idHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(c.X, 1)
+	return nil
+}
+
+type squareHintCircuit struct {
+	X frontend.Variable
+}
+
+func (c *squareHintCircuit) Define(api frontend.API) error {
+	y, err := api.Compiler().NewHint(idHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(api.Mul(y[0], y[0]), c.X)
+	return nil
+}
+
+type sharedHintCircuit struct {
+	X frontend.Variable
+}
+
+func (c *sharedHintCircuit) Define(api frontend.API) error {
+	y, err := api.Compiler().NewHint(idHint, 2, c.X, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(api.Add(y[0], y[1]), c.X)
+	return nil
+}
+
+func TestCheckUnconstrainedWires(t *testing.T) {
+	builders := map[string]frontend.NewBuilder{"r1cs": r1cs.NewBuilder, "scs": scs.NewBuilder}
+	for name, builder := range builders {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			check := func(circuit frontend.Circuit, nbWires int, reason string) {
+				_, err := frontend.Compile(ecc.BN254.ScalarField(), builder, circuit, frontend.WithUnconstrainedWiresCheck())
+				var unconstrained constraint.UnconstrainedWiresError
+				assert.True(errors.As(err, &unconstrained), "expected unconstrained wires error, got %v", err)
+				assert.Len(unconstrained, nbWires)
+				for _, w := range unconstrained {
+					assert.Contains(w.Hint, "idHint")
+					assert.Contains(w.Reason, reason)
+				}
+			}
+			check(&unusedHintCircuit{}, 1, "no constraint")
+			check(&squareHintCircuit{}, 1, "degree 2")
+			check(&sharedHintCircuit{}, 2, "another free hint output")
+
+			_, err := frontend.Compile(ecc.BN254.ScalarField(), builder, &idHintCircuit{}, frontend.WithUnconstrainedWiresCheck())
+			assert.NoError(err)
+			_, err = frontend.Compile(ecc.BN254.ScalarField(), builder, &unusedHintCircuit{})
+			assert.NoError(err)
+
+			// the check doesn't depend on the unconstrained inputs option.
+			_, err = frontend.Compile(ecc.BN254.ScalarField(), builder, &unusedHintCircuit{}, frontend.WithUnconstrainedWiresCheck(), frontend.IgnoreUnconstrainedInputs())
+			assert.Error(err)
+		})
+	}
+}
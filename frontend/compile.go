@@ -153,6 +153,7 @@ func defaultCompileConfig() CompileConfig {
 type CompileConfig struct {
 	Capacity                  int
 	IgnoreUnconstrainedInputs bool
+	CheckUnconstrainedWires   bool
 	CompressThreshold         int
 }
 
@@ -173,6 +174,8 @@ func WithCapacity(capacity int) CompileOption {
 // This option is useful for debugging circuits, but should not be used in
 // production settings as it means that there is a potential error in the
 // circuit definition or that it is possible to optimize witness size.
+//
+// It has no effect on the check enabled by [WithUnconstrainedWiresCheck].
 func IgnoreUnconstrainedInputs() CompileOption {
 	return func(opt *CompileConfig) error {
 		opt.IgnoreUnconstrainedInputs = true
@@ -180,6 +183,23 @@ func IgnoreUnconstrainedInputs() CompileOption {
 	}
 }
 
+// WithUnconstrainedWiresCheck is a compile option which enables the analysis of
+// the compiled constraint system for internal wires and hint outputs which are
+// not uniquely determined by the constraints. If set, then the compiler returns
+// an [constraint.UnconstrainedWiresError] describing the wires found, even if
+// [IgnoreUnconstrainedInputs] is set.
+//
+// The analysis is syntactic and may report false positives for circuits which
+// constrain hint outputs through commitments (for example range checks using
+// lookups). It is meant for auditing circuits, see
+// [constraint.System.CheckUnconstrainedWires].
+func WithUnconstrainedWiresCheck() CompileOption {
+	return func(opt *CompileConfig) error {
+		opt.CheckUnconstrainedWires = true
+		return nil
+	}
+}
+
 // WithCompressThreshold is a compile option which enforces automatic variable
 // compression if the length of the linear expression in the variable exceeds
 // given threshold.
@@ -278,10 +278,10 @@ func (builder *builder) Compile() (constraint.ConstraintSystem, error) {
 		Int("nbConstraints", builder.cs.GetNbConstraints()).
 		Msg("building constraint builder")
 
-	// ensure all inputs and hints are constrained
-	if err := builder.cs.CheckUnconstrainedWires(); err != nil {
-		log.Warn().Msg("circuit has unconstrained inputs")
-		if !builder.config.IgnoreUnconstrainedInputs {
+	// ensure all internal wires and hints are constrained
+	if builder.config.CheckUnconstrainedWires {
+		if err := builder.cs.CheckUnconstrainedWires(); err != nil {
+			log.Warn().Msg("circuit has unconstrained wires")
 			return nil, err
 		}
 	}
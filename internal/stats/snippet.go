@@ -13,6 +13,7 @@ import (
 	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
 	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/poseidon2"
 	"github.com/consensys/gnark/std/math/bits"
 	"github.com/consensys/gnark/std/math/emulated"
 )
@@ -76,6 +77,11 @@ func initSnippets() {
 		mimc.Write(newVariable())
 		_ = mimc.Sum()
 	})
+	registerSnippet("hash/poseidon2", func(api frontend.API, newVariable func() frontend.Variable) {
+		poseidon2, _ := poseidon2.NewPoseidon2(api)
+		poseidon2.Write(newVariable())
+		_ = poseidon2.Sum()
+	}, ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761)
 	registerSnippet("math/emulated/secp256k1_64", func(api frontend.API, newVariable func() frontend.Variable) {
 		secp256k1, _ := emulated.NewField[emulated.Secp256k1Fp](api)
 
@@ -0,0 +1,197 @@
+// Package csdiff compares compiled constraint systems, to track how the emitted
+// constraints change when a circuit or a gadget is modified.
+//
+// [Stats] summarizes a constraint system: its size, the number of instructions
+// per blueprint and the constraints emitted by each source function. [Diff]
+// compares two summaries, including the constraints added and removed in each
+// source function, and [Report.String] formats the differences.
+//
+// To catch constraint-count regressions in CI, record the summary of a circuit
+// in a reference file and compare against it in a test or a benchmark:
+//
+//	func BenchmarkGadget(b *testing.B) {
+//		stats, err := csdiff.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &gadgetCircuit{})
+//		if err != nil {
+//			b.Fatal(err)
+//		}
+//		csdiff.Check(b, "testdata/gadget.json", stats)
+//	}
+//
+// The reference file is written when it doesn't exist, delete it to record a
+// new reference.
+package csdiff
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/debug"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/profile"
+)
+
+// UnknownFunction is the source function of the constraints which can't be
+// attributed to a function.
+const UnknownFunction = "(unknown)"
+
+// Stats summarizes a compiled constraint system.
+type Stats struct {
+	NbConstraints       int `json:"nbConstraints"`
+	NbInstructions      int `json:"nbInstructions"`
+	NbCoefficients      int `json:"nbCoefficients"`
+	NbInternalVariables int `json:"nbInternalVariables"`
+
+	// Blueprints is the number of instructions per blueprint.
+	Blueprints map[string]int `json:"blueprints"`
+
+	// Functions is the number of constraints per source function, that is the
+	// innermost function of the call stack outside of the gnark frontend.
+	Functions map[string]int `json:"functions"`
+
+	// Constraints is the sorted list of the constraints of each source
+	// function, in the text form of [constraint.ConstraintSystem.Dump]. The
+	// internal variables are all written as v, as their numbering changes
+	// whenever a constraint is added or removed before them.
+	Constraints map[string][]string `json:"constraints,omitempty"`
+}
+
+// introspectable is implemented by all the constraint systems, through the
+// embedded constraint.System.
+type introspectable interface {
+	BlueprintUsage() map[string]int
+	ConstraintStack(cID int) []debug.Function
+	ConstraintStrings(r constraint.Resolver) []string
+}
+
+// NewStats returns the summary of the constraint system.
+//
+// The constraints are attributed to source functions using the call stacks of
+// the profiling session p, if not nil, which must have recorded the whole
+// compilation of ccs (see [Compile]). Otherwise, the call stacks of the debug
+// information of the constraints are used, which are available only when the
+// circuit is compiled with the debug build tag.
+func NewStats(ccs constraint.ConstraintSystem, p *profile.Profile) *Stats {
+	s := &Stats{
+		NbConstraints:       ccs.GetNbConstraints(),
+		NbInstructions:      ccs.GetNbInstructions(),
+		NbCoefficients:      ccs.GetNbCoefficients(),
+		NbInternalVariables: ccs.GetNbInternalVariables(),
+		Blueprints:          make(map[string]int),
+		Functions:           make(map[string]int),
+		Constraints:         make(map[string][]string),
+	}
+	system, ok := ccs.(introspectable)
+	if ok {
+		s.Blueprints = system.BlueprintUsage()
+	}
+
+	// source function of each constraint. The samples of the profiling session
+	// are recorded in the order the constraints are added.
+	functions := make([]string, s.NbConstraints)
+	for cID := range functions {
+		functions[cID] = UnknownFunction
+	}
+	if p != nil {
+		for cID, stack := range p.Stacks() {
+			if cID < len(functions) {
+				functions[cID] = sourceFunction(stack)
+			}
+		}
+	} else if ok {
+		var stack []string
+		for cID := range functions {
+			stack = stack[:0]
+			for _, f := range system.ConstraintStack(cID) {
+				stack = append(stack, f.SystemName)
+			}
+			if len(stack) != 0 {
+				functions[cID] = sourceFunction(stack)
+			}
+		}
+	}
+
+	var constraints []string
+	if ok {
+		r := anonymousResolver{ccs, ccs.GetNbPublicVariables() + ccs.GetNbSecretVariables()}
+		constraints = system.ConstraintStrings(r)
+	}
+	for cID, f := range functions {
+		s.Functions[f]++
+		if cID < len(constraints) {
+			s.Constraints[f] = append(s.Constraints[f], constraints[cID])
+		}
+	}
+	for _, c := range s.Constraints {
+		sort.Strings(c)
+	}
+	return s
+}
+
+// anonymousResolver writes all the internal variables as v.
+type anonymousResolver struct {
+	constraint.Resolver
+	nbInputs int
+}
+
+func (r anonymousResolver) VariableToString(vID int) string {
+	if vID >= r.nbInputs {
+		return "v"
+	}
+	return r.Resolver.VariableToString(vID)
+}
+
+// Compile compiles the circuit as frontend.Compile does and returns the summary
+// of the constraint system. The constraints are attributed to source functions
+// with a profiling session. As the profiling sessions record the constraints
+// of all the compilations, Compile must not run concurrently with another
+// compilation.
+func Compile(field *big.Int, newBuilder frontend.NewBuilder, circuit frontend.Circuit, opts ...frontend.CompileOption) (*Stats, error) {
+	p := profile.Start(profile.WithNoOutput())
+	ccs, err := frontend.Compile(field, newBuilder, circuit, opts...)
+	p.Stop()
+	if err != nil {
+		return nil, err
+	}
+	return NewStats(ccs, p), nil
+}
+
+// sourceFunction returns the short name of the innermost function of the stack
+// outside of the gnark frontend.
+func sourceFunction(stack []string) string {
+	const frontendPackage = "github.com/consensys/gnark/frontend"
+	for _, f := range stack {
+		if strings.HasPrefix(f, frontendPackage+"/") || strings.HasPrefix(f, frontendPackage+".") {
+			continue
+		}
+		// keep the last path element, as the profile and the debug information.
+		fe := strings.Split(f, "/")
+		return fe[len(fe)-1]
+	}
+	return UnknownFunction
+}
+
+// Save writes the summary to the file in JSON.
+func (s *Stats) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Load reads a summary written by [Stats.Save].
+func Load(path string) (*Stats, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Stats
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,162 @@
+//go:build !windows
+
+package csdiff_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/profile/csdiff"
+	"github.com/stretchr/testify/require"
+)
+
+type powCircuit struct {
+	X, Y     frontend.Variable
+	exponent int
+}
+
+func (c *powCircuit) Define(api frontend.API) error {
+	res := frontend.Variable(1)
+	for i := 0; i < c.exponent; i++ {
+		res = mul(api, res, c.X)
+	}
+	api.AssertIsEqual(res, c.Y)
+	return nil
+}
+
+func mul(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	return api.Mul(a, b)
+}
+
+func TestDiff(t *testing.T) {
+	assert := require.New(t)
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		before, err := csdiff.Compile(ecc.BN254.ScalarField(), builder, &powCircuit{exponent: 3})
+		assert.NoError(err)
+		after, err := csdiff.Compile(ecc.BN254.ScalarField(), builder, &powCircuit{exponent: 5})
+		assert.NoError(err)
+
+		total := 0
+		for _, n := range after.Functions {
+			total += n
+		}
+		assert.Equal(after.NbConstraints, total)
+		assert.NotZero(after.Functions["csdiff_test.mul"], after.Functions)
+
+		report := csdiff.Diff(before, after)
+		assert.True(report.Regressed())
+		assert.True(report.Changed())
+		assert.Equal(2, report.Constraints.Delta())
+		assert.Equal([]csdiff.Change{{Name: "csdiff_test.mul", Before: 2, After: 4}}, report.Functions)
+		assert.NotEmpty(report.Blueprints)
+		assert.Len(report.ConstraintChanges, 1)
+		assert.Equal("csdiff_test.mul", report.ConstraintChanges[0].Function)
+		assert.Len(report.ConstraintChanges[0].Added, 2)
+		assert.Empty(report.ConstraintChanges[0].Removed)
+		assert.Contains(report.String(), "csdiff_test.mul")
+
+		report = csdiff.Diff(after, after)
+		assert.False(report.Changed())
+	}
+}
+
+type swapCircuit struct {
+	X, Y   frontend.Variable
+	square bool
+}
+
+func (c *swapCircuit) Define(api frontend.API) error {
+	if c.square {
+		api.AssertIsEqual(mul(api, c.X, c.X), c.Y)
+	} else {
+		api.AssertIsEqual(mul(api, c.X, c.Y), c.Y)
+	}
+	return nil
+}
+
+func TestDiffSwappedConstraints(t *testing.T) {
+	assert := require.New(t)
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		before, err := csdiff.Compile(ecc.BN254.ScalarField(), builder, &swapCircuit{square: true})
+		assert.NoError(err)
+		after, err := csdiff.Compile(ecc.BN254.ScalarField(), builder, &swapCircuit{})
+		assert.NoError(err)
+
+		// same number of constraints per function, but a different constraint.
+		report := csdiff.Diff(before, after)
+		assert.Empty(report.Functions)
+		assert.False(report.Regressed())
+		assert.True(report.Changed())
+		assert.Len(report.ConstraintChanges, 1)
+		change := report.ConstraintChanges[0]
+		assert.Equal("csdiff_test.mul", change.Function)
+		assert.Len(change.Added, 1)
+		assert.Len(change.Removed, 1)
+		assert.Contains(change.Added[0], "Y")
+		assert.Contains(report.String(), "+ "+change.Added[0])
+		assert.Contains(report.String(), "- "+change.Removed[0])
+	}
+}
+
+func TestNewStatsWithoutProfile(t *testing.T) {
+	assert := require.New(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powCircuit{exponent: 3})
+	assert.NoError(err)
+
+	s := csdiff.NewStats(ccs, nil)
+	assert.Equal(ccs.GetNbConstraints(), s.NbConstraints)
+	total := 0
+	for _, n := range s.Functions {
+		total += n
+	}
+	assert.Equal(s.NbConstraints, total)
+	total = 0
+	for _, n := range s.Blueprints {
+		total += n
+	}
+	assert.Equal(ccs.GetNbInstructions(), total)
+}
+
+func TestCheck(t *testing.T) {
+	assert := require.New(t)
+	reference := filepath.Join(t.TempDir(), "pow.json")
+
+	before, err := csdiff.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powCircuit{exponent: 3})
+	assert.NoError(err)
+	csdiff.Check(t, reference, before)
+
+	loaded, err := csdiff.Load(reference)
+	assert.NoError(err)
+	assert.Equal(before, loaded)
+
+	// fewer constraints only logs the report.
+	after, err := csdiff.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powCircuit{exponent: 2})
+	assert.NoError(err)
+	csdiff.Check(t, reference, after)
+
+	after, err = csdiff.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &powCircuit{exponent: 4})
+	assert.NoError(err)
+	var tb recorder
+	csdiff.Check(&tb, reference, after)
+	assert.True(tb.failed)
+	assert.True(strings.Contains(tb.msg, "regression"), tb.msg)
+}
+
+// recorder captures the failure of a check expected to fail.
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.msg = format
+}
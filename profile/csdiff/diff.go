@@ -0,0 +1,229 @@
+package csdiff
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+// Change is the difference of a count between two constraint systems.
+type Change struct {
+	Name          string
+	Before, After int
+}
+
+// Delta returns After - Before.
+func (c Change) Delta() int {
+	return c.After - c.Before
+}
+
+// ConstraintChange lists the constraints added and removed in a source
+// function, in the text form of [Stats.Constraints].
+type ConstraintChange struct {
+	Function       string
+	Added, Removed []string
+}
+
+// Report is the difference between two constraint systems.
+type Report struct {
+	Constraints       Change
+	Instructions      Change
+	Coefficients      Change
+	InternalVariables Change
+
+	// Functions lists the source functions whose number of constraints
+	// changed, by decreasing number of added constraints.
+	Functions []Change
+
+	// Blueprints lists the blueprints whose number of instructions changed,
+	// by decreasing number of added instructions.
+	Blueprints []Change
+
+	// ConstraintChanges lists the source functions where constraints were added
+	// or removed, even if their number of constraints didn't change, by
+	// function name. It is empty if one of the summaries has no constraints,
+	// for example a reference written by a previous version.
+	ConstraintChanges []ConstraintChange
+}
+
+// Diff returns the differences from before to after.
+func Diff(before, after *Stats) *Report {
+	return &Report{
+		Constraints:       Change{"constraints", before.NbConstraints, after.NbConstraints},
+		Instructions:      Change{"instructions", before.NbInstructions, after.NbInstructions},
+		Coefficients:      Change{"coefficients", before.NbCoefficients, after.NbCoefficients},
+		InternalVariables: Change{"internal variables", before.NbInternalVariables, after.NbInternalVariables},
+		Functions:         diffCounts(before.Functions, after.Functions),
+		Blueprints:        diffCounts(before.Blueprints, after.Blueprints),
+		ConstraintChanges: diffConstraints(before.Constraints, after.Constraints),
+	}
+}
+
+func diffConstraints(before, after map[string][]string) []ConstraintChange {
+	if len(before) == 0 || len(after) == 0 {
+		return nil
+	}
+	functions := make(map[string]struct{})
+	for f := range before {
+		functions[f] = struct{}{}
+	}
+	for f := range after {
+		functions[f] = struct{}{}
+	}
+	var res []ConstraintChange
+	for f := range functions {
+		added, removed := diffSorted(before[f], after[f])
+		if len(added) != 0 || len(removed) != 0 {
+			res = append(res, ConstraintChange{f, added, removed})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Function < res[j].Function
+	})
+	return res
+}
+
+// diffSorted returns the elements of the sorted multiset after which are not
+// in before, and the ones of before which are not in after.
+func diffSorted(before, after []string) (added, removed []string) {
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			i++
+			j++
+		case before[i] < after[j]:
+			removed = append(removed, before[i])
+			i++
+		default:
+			added = append(added, after[j])
+			j++
+		}
+	}
+	removed = append(removed, before[i:]...)
+	added = append(added, after[j:]...)
+	return added, removed
+}
+
+func diffCounts(before, after map[string]int) []Change {
+	var res []Change
+	for name, b := range before {
+		if a := after[name]; a != b {
+			res = append(res, Change{name, b, a})
+		}
+	}
+	for name, a := range after {
+		if _, ok := before[name]; !ok && a != 0 {
+			res = append(res, Change{name, 0, a})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if di, dj := res[i].Delta(), res[j].Delta(); di != dj {
+			return di > dj
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// Regressed returns true if the number of constraints increased.
+func (r *Report) Regressed() bool {
+	return r.Constraints.Delta() > 0
+}
+
+// Changed returns true if any of the compared counts changed.
+func (r *Report) Changed() bool {
+	for _, c := range []Change{r.Constraints, r.Instructions, r.Coefficients, r.InternalVariables} {
+		if c.Delta() != 0 {
+			return true
+		}
+	}
+	return len(r.Functions) != 0 || len(r.Blueprints) != 0 || len(r.ConstraintChanges) != 0
+}
+
+// maxListedConstraints is the maximum number of added or removed constraints
+// listed for each source function by [Report.String].
+const maxListedConstraints = 10
+
+// String formats the report as aligned tables, followed by the constraints
+// added (+) and removed (-) in each source function.
+func (r *Report) String() string {
+	var sbb strings.Builder
+	w := tabwriter.NewWriter(&sbb, 0, 4, 2, ' ', tabwriter.AlignRight)
+	writeRow := func(c Change) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%+d\t%s\t\n", c.Name, c.Before, c.After, c.Delta(), percent(c))
+	}
+	writeTable := func(title string, changes []Change) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s\tbefore\tafter\tdelta\t\t\n", title)
+		for _, c := range changes {
+			writeRow(c)
+		}
+	}
+	writeTable("system", []Change{r.Constraints, r.Instructions, r.Coefficients, r.InternalVariables})
+	writeTable("source function (constraints)", r.Functions)
+	writeTable("blueprint (instructions)", r.Blueprints)
+	w.Flush()
+
+	writeConstraints := func(prefix string, constraints []string) {
+		for i, c := range constraints {
+			if i == maxListedConstraints {
+				fmt.Fprintf(&sbb, "%s ... %d more\n", prefix, len(constraints)-i)
+				return
+			}
+			fmt.Fprintf(&sbb, "%s %s\n", prefix, c)
+		}
+	}
+	for _, c := range r.ConstraintChanges {
+		fmt.Fprintf(&sbb, "\n%s\n", c.Function)
+		writeConstraints("+", c.Added)
+		writeConstraints("-", c.Removed)
+	}
+	return strings.TrimPrefix(sbb.String(), "\n")
+}
+
+func percent(c Change) string {
+	if c.Before == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%+.1f%%", 100*float64(c.Delta())/float64(c.Before))
+}
+
+// Check compares the summary with the reference stored in the file and marks
+// the test or benchmark as failed, with the report, if the number of
+// constraints increased. If the reference file doesn't exist, it is created
+// from the summary. For benchmarks, the size of the constraint system is
+// reported as custom metrics.
+func Check(tb testing.TB, referencePath string, s *Stats) {
+	tb.Helper()
+	if b, ok := tb.(*testing.B); ok {
+		b.ReportMetric(float64(s.NbConstraints), "constraints")
+		b.ReportMetric(float64(s.NbCoefficients), "coefficients")
+	}
+
+	reference, err := Load(referencePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := s.Save(referencePath); err != nil {
+			tb.Fatalf("writing reference %s: %v", referencePath, err)
+		}
+		tb.Logf("reference %s written", referencePath)
+		return
+	}
+	if err != nil {
+		tb.Fatalf("reading reference %s: %v", referencePath, err)
+	}
+
+	report := Diff(reference, s)
+	switch {
+	case report.Regressed():
+		tb.Errorf("constraint count regression against %s:\n%s", referencePath, report)
+	case report.Changed():
+		tb.Logf("constraint system changed against %s (delete it to record a new reference):\n%s", referencePath, report)
+	}
+}
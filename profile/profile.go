@@ -141,6 +141,20 @@ func (p *Profile) NbConstraints() int {
 	return len(p.pprof.Sample)
 }
 
+// Stacks returns the call stack of each constraint recorded by the session, in
+// order, as fully qualified function names with the innermost call first. The
+// private functions of the builders are omitted, as in the pprof profile.
+func (p *Profile) Stacks() [][]string {
+	res := make([][]string, len(p.pprof.Sample))
+	for i, s := range p.pprof.Sample {
+		res[i] = make([]string, len(s.Location))
+		for j, l := range s.Location {
+			res[i][j] = l.Line[0].Function.SystemName
+		}
+	}
+	return res
+}
+
 // Top return a similar output than pprof top command
 func (p *Profile) Top() string {
 	r := report.NewDefault(&p.pprof, report.Options{
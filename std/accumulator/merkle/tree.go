@@ -0,0 +1,229 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Tree is a native fixed-depth Merkle tree which builds the witnesses for the
+// in-circuit gadgets [MerkleProof], [UpdateProof], [BatchUpdateProof] and
+// [InsertProof].
+//
+// The leaves are hashed and the nodes are computed in the same way as in the
+// in-circuit gadgets, so the native hash function must be the counterpart of
+// the in-circuit hash function (for example MiMC from gnark-crypto and
+// [github.com/consensys/gnark/std/hash/mimc]). All leaves are initialized to
+// data 0 and only the non-empty subtrees are stored, so that trees of large
+// depth can be used.
+type Tree struct {
+	h         hash.Hash
+	depth     int
+	nextIndex uint64
+	leaves    map[uint64][]byte
+	// nodes[0] are the leaf hashes and nodes[depth] contains the root
+	nodes []map[uint64][]byte
+	// empty[i] is the root of an empty subtree of depth i
+	empty [][]byte
+}
+
+// NewTree returns a new empty Merkle tree of the given depth using the native
+// hash function h. The size of the leaf data is h.BlockSize().
+func NewTree(h hash.Hash, depth int) (*Tree, error) {
+	if depth < 0 || depth > 63 {
+		return nil, fmt.Errorf("invalid depth %d", depth)
+	}
+	t := &Tree{
+		h:      h,
+		depth:  depth,
+		leaves: make(map[uint64][]byte),
+		nodes:  make([]map[uint64][]byte, depth+1),
+		empty:  make([][]byte, depth+1),
+	}
+	for i := range t.nodes {
+		t.nodes[i] = make(map[uint64][]byte)
+	}
+	t.empty[0] = t.leafSum(make([]byte, h.BlockSize()))
+	for i := 1; i <= depth; i++ {
+		t.empty[i] = t.nodeSum(t.empty[i-1], t.empty[i-1])
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// NextIndex returns the index of the next free slot used by [Tree.Insert].
+func (t *Tree) NextIndex() uint64 {
+	return t.nextIndex
+}
+
+// Root returns the current root of the tree.
+func (t *Tree) Root() []byte {
+	return t.node(t.depth, 0)
+}
+
+// Leaf returns the data of the leaf at index.
+func (t *Tree) Leaf(index uint64) []byte {
+	if l, ok := t.leaves[index]; ok {
+		return l
+	}
+	return make([]byte, t.h.BlockSize())
+}
+
+// Path returns the siblings of the leaf at index, from the leaf level up to
+// the children of the root.
+func (t *Tree) Path(index uint64) [][]byte {
+	path := make([][]byte, t.depth)
+	for i := 0; i < t.depth; i++ {
+		path[i] = t.node(i, index^1)
+		index >>= 1
+	}
+	return path
+}
+
+// Prove returns the membership proof of the leaf at index in the format
+// expected by [MerkleProof.VerifyProof].
+func (t *Tree) Prove(index uint64) (MerkleProof, error) {
+	if err := t.checkIndex(index); err != nil {
+		return MerkleProof{}, err
+	}
+	path := t.Path(index)
+	res := MerkleProof{
+		RootHash: t.Root(),
+		Path:     make([]frontend.Variable, t.depth+1),
+	}
+	res.Path[0] = t.Leaf(index)
+	for i := range path {
+		res.Path[i+1] = path[i]
+	}
+	return res, nil
+}
+
+// Set sets the data of the leaf at index and returns the witness of the
+// update. The data must be at most h.BlockSize() bytes long.
+func (t *Tree) Set(index uint64, data []byte) (UpdateProof, error) {
+	oldRoot := t.Root()
+	lu, err := t.set(index, data)
+	if err != nil {
+		return UpdateProof{}, err
+	}
+	return UpdateProof{
+		OldRoot: oldRoot,
+		NewRoot: t.Root(),
+		Update:  lu,
+	}, nil
+}
+
+// BatchSet sequentially sets the data of the leaves at indices and returns the
+// witness of the updates. If an error occurs, then the tree may be partially
+// updated.
+func (t *Tree) BatchSet(indices []uint64, data [][]byte) (BatchUpdateProof, error) {
+	if len(indices) != len(data) {
+		return BatchUpdateProof{}, errors.New("number of indices and leaves mismatch")
+	}
+	res := BatchUpdateProof{
+		OldRoot: t.Root(),
+		Updates: make([]LeafUpdate, len(indices)),
+	}
+	for i := range indices {
+		lu, err := t.set(indices[i], data[i])
+		if err != nil {
+			return BatchUpdateProof{}, err
+		}
+		res.Updates[i] = lu
+	}
+	res.NewRoot = t.Root()
+	return res, nil
+}
+
+// Insert appends the leaves at the next free indices and returns the witness
+// of the insertion. The index of the first inserted leaf is the value of
+// [Tree.NextIndex] before the call.
+func (t *Tree) Insert(data ...[]byte) (InsertProof, error) {
+	if t.nextIndex+uint64(len(data)) > 1<<t.depth {
+		return InsertProof{}, errors.New("tree is full")
+	}
+	res := InsertProof{
+		OldRoot: t.Root(),
+		Leaves:  make([]frontend.Variable, len(data)),
+		Paths:   make([][]frontend.Variable, len(data)),
+	}
+	for i := range data {
+		lu, err := t.set(t.nextIndex, data[i])
+		if err != nil {
+			return InsertProof{}, err
+		}
+		res.Leaves[i] = lu.NewLeaf
+		res.Paths[i] = lu.Path
+		t.nextIndex++
+	}
+	res.NewRoot = t.Root()
+	return res, nil
+}
+
+func (t *Tree) set(index uint64, data []byte) (LeafUpdate, error) {
+	if err := t.checkIndex(index); err != nil {
+		return LeafUpdate{}, err
+	}
+	if len(data) > t.h.BlockSize() {
+		return LeafUpdate{}, fmt.Errorf("leaf data too long: %d > %d", len(data), t.h.BlockSize())
+	}
+	leaf := make([]byte, t.h.BlockSize())
+	copy(leaf[len(leaf)-len(data):], data)
+
+	path := t.Path(index)
+	lu := LeafUpdate{
+		OldLeaf: t.Leaf(index),
+		NewLeaf: leaf,
+		Path:    make([]frontend.Variable, len(path)),
+	}
+	for i := range path {
+		lu.Path[i] = path[i]
+	}
+
+	t.leaves[index] = leaf
+	sum := t.leafSum(leaf)
+	t.nodes[0][index] = sum
+	for i := 0; i < t.depth; i++ {
+		if index&1 == 0 {
+			sum = t.nodeSum(sum, path[i])
+		} else {
+			sum = t.nodeSum(path[i], sum)
+		}
+		index >>= 1
+		t.nodes[i+1][index] = sum
+	}
+	return lu, nil
+}
+
+func (t *Tree) checkIndex(index uint64) error {
+	if index >= 1<<t.depth {
+		return fmt.Errorf("index %d out of range for depth %d", index, t.depth)
+	}
+	return nil
+}
+
+func (t *Tree) node(level int, index uint64) []byte {
+	if n, ok := t.nodes[level][index]; ok {
+		return n
+	}
+	return t.empty[level]
+}
+
+func (t *Tree) leafSum(data []byte) []byte {
+	t.h.Reset()
+	t.h.Write(data)
+	return t.h.Sum(nil)
+}
+
+func (t *Tree) nodeSum(a, b []byte) []byte {
+	t.h.Reset()
+	t.h.Write(a)
+	t.h.Write(b)
+	return t.h.Sum(nil)
+}
@@ -0,0 +1,143 @@
+package merkle
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// LeafUpdate stores the witness for replacing a single leaf of a Merkle tree.
+// The leaves are given as data, i.e. before applying the leaf hash, as in
+// [MerkleProof].
+type LeafUpdate struct {
+
+	// OldLeaf is the data of the leaf before the update.
+	OldLeaf frontend.Variable
+
+	// NewLeaf is the data of the leaf after the update.
+	NewLeaf frontend.Variable
+
+	// Path is the list of siblings from the leaf level up to the children of
+	// the root. The siblings do not change during the update.
+	Path []frontend.Variable
+}
+
+// UpdateProof stores the witness for proving that replacing a single leaf
+// transforms the tree with root OldRoot into the tree with root NewRoot.
+type UpdateProof struct {
+
+	// OldRoot root of the Merkle tree before the update
+	OldRoot frontend.Variable
+
+	// NewRoot root of the Merkle tree after the update
+	NewRoot frontend.Variable
+
+	// Update the leaf which is updated
+	Update LeafUpdate
+}
+
+// BatchUpdateProof stores the witness for proving that applying a sequence of
+// leaf updates transforms the tree with root OldRoot into the tree with root
+// NewRoot. The path of every update is relative to the tree obtained after
+// applying all previous updates.
+type BatchUpdateProof struct {
+
+	// OldRoot root of the Merkle tree before the updates
+	OldRoot frontend.Variable
+
+	// NewRoot root of the Merkle tree after the updates
+	NewRoot frontend.Variable
+
+	// Updates the sequence of leaf updates
+	Updates []LeafUpdate
+}
+
+// InsertProof stores the witness for proving that appending leaves at the next
+// free indices transforms the tree with root OldRoot into the tree with root
+// NewRoot. The free slots of the tree have leaf data 0.
+type InsertProof struct {
+
+	// OldRoot root of the Merkle tree before the insertion
+	OldRoot frontend.Variable
+
+	// NewRoot root of the Merkle tree after the insertion
+	NewRoot frontend.Variable
+
+	// Leaves data of the inserted leaves
+	Leaves []frontend.Variable
+
+	// Paths the siblings of the inserted leaves. The path of every inserted
+	// leaf is relative to the tree obtained after inserting all previous
+	// leaves.
+	Paths [][]frontend.Variable
+}
+
+// computeRoots returns the roots of the tree before and after applying the
+// update at the index given by its binary decomposition.
+func (lu *LeafUpdate) computeRoots(api frontend.API, h hash.FieldHasher, binIndex []frontend.Variable) (oldRoot, newRoot frontend.Variable) {
+	oldSum := leafSum(api, h, lu.OldLeaf)
+	newSum := leafSum(api, h, lu.NewLeaf)
+	for i := range lu.Path {
+		d1 := api.Select(binIndex[i], lu.Path[i], oldSum)
+		d2 := api.Select(binIndex[i], oldSum, lu.Path[i])
+		oldSum = nodeSum(api, h, d1, d2)
+
+		d1 = api.Select(binIndex[i], lu.Path[i], newSum)
+		d2 = api.Select(binIndex[i], newSum, lu.Path[i])
+		newSum = nodeSum(api, h, d1, d2)
+	}
+	return oldSum, newSum
+}
+
+// apply asserts that the update at index is consistent with the root and
+// returns the root after the update.
+func (lu *LeafUpdate) apply(api frontend.API, h hash.FieldHasher, root, index frontend.Variable) frontend.Variable {
+	binIndex := api.ToBinary(index, len(lu.Path))
+	oldRoot, newRoot := lu.computeRoots(api, h, binIndex)
+	api.AssertIsEqual(oldRoot, root)
+	return newRoot
+}
+
+// VerifyUpdate asserts that replacing the leaf at index from
+// mp.Update.OldLeaf to mp.Update.NewLeaf transforms the Merkle tree with root
+// mp.OldRoot into the Merkle tree with root mp.NewRoot. The index is
+// constrained to be less than 2^len(mp.Update.Path).
+func (mp *UpdateProof) VerifyUpdate(api frontend.API, h hash.FieldHasher, index frontend.Variable) {
+	newRoot := mp.Update.apply(api, h, mp.OldRoot, index)
+	api.AssertIsEqual(newRoot, mp.NewRoot)
+}
+
+// VerifyBatchUpdate asserts that sequentially applying mp.Updates at the given
+// indices transforms the Merkle tree with root mp.OldRoot into the Merkle tree
+// with root mp.NewRoot. The indices do not have to be distinct.
+func (mp *BatchUpdateProof) VerifyBatchUpdate(api frontend.API, h hash.FieldHasher, indices []frontend.Variable) error {
+	if len(indices) != len(mp.Updates) {
+		return fmt.Errorf("number of indices %d does not match number of updates %d", len(indices), len(mp.Updates))
+	}
+	root := mp.OldRoot
+	for i := range mp.Updates {
+		root = mp.Updates[i].apply(api, h, root, indices[i])
+	}
+	api.AssertIsEqual(root, mp.NewRoot)
+	return nil
+}
+
+// VerifyInsert asserts that appending mp.Leaves at the indices nextIndex,
+// nextIndex+1, ... transforms the Merkle tree with root mp.OldRoot into the
+// Merkle tree with root mp.NewRoot. The slots must be free, i.e. have leaf
+// data 0, before the insertion. It returns the next free index after the
+// insertion.
+func (mp *InsertProof) VerifyInsert(api frontend.API, h hash.FieldHasher, nextIndex frontend.Variable) (frontend.Variable, error) {
+	if len(mp.Leaves) != len(mp.Paths) {
+		return nil, fmt.Errorf("number of leaves %d does not match number of paths %d", len(mp.Leaves), len(mp.Paths))
+	}
+	root := mp.OldRoot
+	for i := range mp.Leaves {
+		lu := LeafUpdate{OldLeaf: 0, NewLeaf: mp.Leaves[i], Path: mp.Paths[i]}
+		root = lu.apply(api, h, root, nextIndex)
+		nextIndex = api.Add(nextIndex, 1)
+	}
+	api.AssertIsEqual(root, mp.NewRoot)
+	return nextIndex, nil
+}
@@ -0,0 +1,165 @@
+package merkle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+func randomLeaf(assert *test.Assert) []byte {
+	leaf, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	return leaf.Bytes()
+}
+
+func TestTreeCompatibility(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+	tree, err := NewTree(hash.MIMC_BN254.New(), depth)
+	assert.NoError(err)
+	var buf bytes.Buffer
+	for i := 0; i < 1<<depth; i++ {
+		leaf := randomLeaf(assert)
+		_, err = tree.Insert(leaf)
+		assert.NoError(err)
+		buf.Write(tree.Leaf(uint64(i)))
+	}
+	root, path, _, err := merkletree.BuildReaderProof(&buf, hash.MIMC_BN254.New(), 32, 3)
+	assert.NoError(err)
+	assert.Equal(root, tree.Root())
+	proof, err := tree.Prove(3)
+	assert.NoError(err)
+	for i := range path {
+		assert.Equal(path[i], proof.Path[i])
+	}
+	_, err = tree.Insert(randomLeaf(assert))
+	assert.Error(err)
+}
+
+type updateCircuit struct {
+	Proof UpdateProof
+	Index frontend.Variable
+}
+
+func (c *updateCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyUpdate(api, &h, c.Index)
+	return nil
+}
+
+func TestUpdate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 5
+	tree, err := NewTree(hash.MIMC_BN254.New(), depth)
+	assert.NoError(err)
+	for i := 0; i < 7; i++ {
+		_, err = tree.Insert(randomLeaf(assert))
+		assert.NoError(err)
+	}
+	proof, err := tree.Set(5, randomLeaf(assert))
+	assert.NoError(err)
+
+	circuit := updateCircuit{Proof: UpdateProof{Update: LeafUpdate{Path: make([]frontend.Variable, depth)}}}
+	assignment := updateCircuit{Proof: proof, Index: 5}
+	invalid := updateCircuit{Proof: proof, Index: 4}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&invalid), test.WithCurves(ecc.BN254))
+}
+
+type batchUpdateCircuit struct {
+	Proof   BatchUpdateProof
+	Indices []frontend.Variable
+}
+
+func (c *batchUpdateCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return c.Proof.VerifyBatchUpdate(api, &h, c.Indices)
+}
+
+func TestBatchUpdate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 5
+	tree, err := NewTree(hash.MIMC_BN254.New(), depth)
+	assert.NoError(err)
+	indices := []uint64{3, 17, 3, 31}
+	leaves := make([][]byte, len(indices))
+	for i := range leaves {
+		leaves[i] = randomLeaf(assert)
+	}
+	proof, err := tree.BatchSet(indices, leaves)
+	assert.NoError(err)
+
+	circuit := batchUpdateCircuit{
+		Proof:   BatchUpdateProof{Updates: make([]LeafUpdate, len(indices))},
+		Indices: make([]frontend.Variable, len(indices)),
+	}
+	for i := range circuit.Proof.Updates {
+		circuit.Proof.Updates[i].Path = make([]frontend.Variable, depth)
+	}
+	assignment := batchUpdateCircuit{Proof: proof, Indices: make([]frontend.Variable, len(indices))}
+	invalid := batchUpdateCircuit{Proof: proof, Indices: make([]frontend.Variable, len(indices))}
+	for i := range indices {
+		assignment.Indices[i] = indices[i]
+		invalid.Indices[i] = indices[i]
+	}
+	invalid.Indices[0], invalid.Indices[1] = indices[1], indices[0]
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&invalid), test.WithCurves(ecc.BN254))
+}
+
+type insertCircuit struct {
+	Proof         InsertProof
+	NextIndex     frontend.Variable
+	NextIndexNext frontend.Variable
+}
+
+func (c *insertCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	next, err := c.Proof.VerifyInsert(api, &h, c.NextIndex)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(next, c.NextIndexNext)
+	return nil
+}
+
+func TestInsert(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+	const nbInserts = 3
+	tree, err := NewTree(hash.MIMC_BN254.New(), depth)
+	assert.NoError(err)
+	for i := 0; i < 5; i++ {
+		_, err = tree.Insert(randomLeaf(assert))
+		assert.NoError(err)
+	}
+	nextIndex := tree.NextIndex()
+	leaves := make([][]byte, nbInserts)
+	for i := range leaves {
+		leaves[i] = randomLeaf(assert)
+	}
+	proof, err := tree.Insert(leaves...)
+	assert.NoError(err)
+
+	circuit := insertCircuit{Proof: InsertProof{Leaves: make([]frontend.Variable, nbInserts), Paths: make([][]frontend.Variable, nbInserts)}}
+	for i := range circuit.Proof.Paths {
+		circuit.Proof.Paths[i] = make([]frontend.Variable, depth)
+	}
+	assignment := insertCircuit{Proof: proof, NextIndex: nextIndex, NextIndexNext: tree.NextIndex()}
+	invalid := insertCircuit{Proof: proof, NextIndex: nextIndex - 1, NextIndexNext: tree.NextIndex() - 1}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&invalid), test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,159 @@
+// Package sparsemerkle provides ZKP-circuit functions to verify membership,
+// non-membership and updates in a sparse Merkle tree.
+//
+// The tree has a fixed depth d and maps keys, which are native field elements,
+// to values. The slot of a key is given by the d least significant bits of the
+// key. An empty slot has leaf hash 0 and a slot storing value v for key k has
+// leaf hash H(k, v). The inner nodes are computed as H(left, right).
+//
+// As the key is stored in the leaf, then non-membership of a key can be proven
+// either by showing that its slot is empty or that it is occupied by a
+// different key with the same slot. In the latter case the key can not be
+// inserted in the tree.
+//
+// Two keys sharing their d least significant bits can thus not be stored
+// together: the second insertion fails with [ErrSlotOccupied]. Keys chosen by
+// an untrusted party should be hashed before being used, with a depth large
+// enough to make collisions unlikely. Alternatively, when the keys are smaller
+// than 2^d, no two keys share a slot.
+//
+// The native counterpart of the circuit is [Tree], which builds the witnesses
+// for the in-circuit verification.
+package sparsemerkle
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// MembershipProof stores the witness for proving that a key maps to a value in
+// the tree.
+type MembershipProof struct {
+
+	// Root root of the sparse Merkle tree
+	Root frontend.Variable
+
+	// Path siblings from the leaf level up to the children of the root
+	Path []frontend.Variable
+}
+
+// NonMembershipProof stores the witness for proving that a key is not in the
+// tree.
+type NonMembershipProof struct {
+
+	// Root root of the sparse Merkle tree
+	Root frontend.Variable
+
+	// IsEmpty is 1 if the slot of the key is empty and 0 if the slot is
+	// occupied by OtherKey.
+	IsEmpty frontend.Variable
+
+	// OtherKey is the key occupying the slot if IsEmpty is 0. Otherwise it is
+	// ignored.
+	OtherKey frontend.Variable
+
+	// OtherValue is the value of OtherKey if IsEmpty is 0. Otherwise it is
+	// ignored.
+	OtherValue frontend.Variable
+
+	// Path siblings from the leaf level up to the children of the root
+	Path []frontend.Variable
+}
+
+// UpsertProof stores the witness for proving that setting the value of a key
+// transforms the tree with root OldRoot into the tree with root NewRoot.
+type UpsertProof struct {
+
+	// OldRoot root of the sparse Merkle tree before the upsert
+	OldRoot frontend.Variable
+
+	// NewRoot root of the sparse Merkle tree after the upsert
+	NewRoot frontend.Variable
+
+	// IsInsert is 1 if the key is not in the tree before the upsert and 0 if
+	// the value of the key is updated.
+	IsInsert frontend.Variable
+
+	// OldValue is the value of the key before the upsert if IsInsert is 0.
+	// Otherwise it is ignored.
+	OldValue frontend.Variable
+
+	// Path siblings from the leaf level up to the children of the root
+	Path []frontend.Variable
+}
+
+// leafSum returns the hash of the leaf storing value for key.
+func leafSum(h hash.FieldHasher, key, value frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(key, value)
+	return h.Sum()
+}
+
+// nodeSum returns the hash of the inner node with children a and b.
+func nodeSum(h hash.FieldHasher, a, b frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(a, b)
+	return h.Sum()
+}
+
+// slotBits returns the bits defining the slot of key, from the leaf level up.
+func slotBits(api frontend.API, key frontend.Variable, depth int) []frontend.Variable {
+	if depth > api.Compiler().FieldBitLen() {
+		panic("depth larger than field bit length")
+	}
+	return api.ToBinary(key)[:depth]
+}
+
+// computeRoot returns the root of the tree given the leaf hash and the path.
+func computeRoot(api frontend.API, h hash.FieldHasher, leaf frontend.Variable, bits, path []frontend.Variable) frontend.Variable {
+	sum := leaf
+	for i := range path {
+		d1 := api.Select(bits[i], path[i], sum)
+		d2 := api.Select(bits[i], sum, path[i])
+		sum = nodeSum(h, d1, d2)
+	}
+	return sum
+}
+
+// VerifyMembership asserts that key maps to value in the tree with root
+// mp.Root.
+func (mp *MembershipProof) VerifyMembership(api frontend.API, h hash.FieldHasher, key, value frontend.Variable) {
+	bits := slotBits(api, key, len(mp.Path))
+	root := computeRoot(api, h, leafSum(h, key, value), bits, mp.Path)
+	api.AssertIsEqual(root, mp.Root)
+}
+
+// VerifyNonMembership asserts that key is not in the tree with root mp.Root.
+func (mp *NonMembershipProof) VerifyNonMembership(api frontend.API, h hash.FieldHasher, key frontend.Variable) {
+	api.AssertIsBoolean(mp.IsEmpty)
+	bits := slotBits(api, key, len(mp.Path))
+
+	// if the slot is occupied, then the other key must be different from the
+	// key but must be stored in the same slot.
+	otherKey := api.Select(mp.IsEmpty, 0, mp.OtherKey)
+	key = api.Select(mp.IsEmpty, 1, key)
+	api.AssertIsDifferent(key, otherKey)
+	otherBits := slotBits(api, otherKey, len(mp.Path))
+	for i := range bits {
+		api.AssertIsEqual(api.Mul(api.Sub(1, mp.IsEmpty), api.Sub(bits[i], otherBits[i])), 0)
+	}
+
+	leaf := api.Select(mp.IsEmpty, 0, leafSum(h, mp.OtherKey, mp.OtherValue))
+	root := computeRoot(api, h, leaf, bits, mp.Path)
+	api.AssertIsEqual(root, mp.Root)
+}
+
+// VerifyUpsert asserts that setting the value of key to newValue transforms
+// the tree with root mp.OldRoot into the tree with root mp.NewRoot. If
+// mp.IsInsert is 1, then the slot of the key must be empty before the upsert,
+// so a key whose slot is used by another key can not be inserted. Otherwise, the key must map to mp.OldValue before the upsert.
+func (mp *UpsertProof) VerifyUpsert(api frontend.API, h hash.FieldHasher, key, newValue frontend.Variable) {
+	api.AssertIsBoolean(mp.IsInsert)
+	bits := slotBits(api, key, len(mp.Path))
+	oldLeaf := api.Select(mp.IsInsert, 0, leafSum(h, key, mp.OldValue))
+	newLeaf := leafSum(h, key, newValue)
+	oldRoot := computeRoot(api, h, oldLeaf, bits, mp.Path)
+	newRoot := computeRoot(api, h, newLeaf, bits, mp.Path)
+	api.AssertIsEqual(oldRoot, mp.OldRoot)
+	api.AssertIsEqual(newRoot, mp.NewRoot)
+}
@@ -0,0 +1,142 @@
+package sparsemerkle
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+const testDepth = 4
+
+func b(v int64) []byte {
+	return big.NewInt(v).Bytes()
+}
+
+func newTestTree(assert *test.Assert) *Tree {
+	tree, err := NewTree(hash.MIMC_BN254.New(), testDepth)
+	assert.NoError(err)
+	for _, kv := range [][2]int64{{1, 10}, {2, 20}, {7, 70}} {
+		_, err = tree.Upsert(b(kv[0]), b(kv[1]))
+		assert.NoError(err)
+	}
+	return tree
+}
+
+type membershipCircuit struct {
+	Proof MembershipProof
+	Key   frontend.Variable
+	Value frontend.Variable
+}
+
+func (c *membershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyMembership(api, &h, c.Key, c.Value)
+	return nil
+}
+
+func TestMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(assert)
+	proof, err := tree.ProveMembership(b(2))
+	assert.NoError(err)
+	_, err = tree.ProveMembership(b(3))
+	assert.Error(err)
+
+	circuit := membershipCircuit{Proof: MembershipProof{Path: make([]frontend.Variable, testDepth)}}
+	assignment := membershipCircuit{Proof: proof, Key: 2, Value: 20}
+	invalid := membershipCircuit{Proof: proof, Key: 2, Value: 21}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&invalid), test.WithCurves(ecc.BN254))
+}
+
+type nonMembershipCircuit struct {
+	Proof NonMembershipProof
+	Key   frontend.Variable
+}
+
+func (c *nonMembershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyNonMembership(api, &h, c.Key)
+	return nil
+}
+
+func TestNonMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(assert)
+	circuit := nonMembershipCircuit{Proof: NonMembershipProof{Path: make([]frontend.Variable, testDepth)}}
+
+	// empty slot
+	emptyProof, err := tree.ProveNonMembership(b(3))
+	assert.NoError(err)
+	// slot occupied by key 1
+	occupiedProof, err := tree.ProveNonMembership(b(1 + 1<<testDepth))
+	assert.NoError(err)
+	assert.Equal(0, occupiedProof.IsEmpty)
+	_, err = tree.ProveNonMembership(b(7))
+	assert.Error(err)
+
+	// claiming that the occupied slot is empty
+	forgedEmpty := occupiedProof
+	forgedEmpty.IsEmpty = 1
+	// using the slot occupant to prove non-membership of the occupant
+	assert.CheckCircuit(&circuit,
+		test.WithValidAssignment(&nonMembershipCircuit{Proof: emptyProof, Key: 3}),
+		test.WithValidAssignment(&nonMembershipCircuit{Proof: occupiedProof, Key: 1 + 1<<testDepth}),
+		test.WithInvalidAssignment(&nonMembershipCircuit{Proof: emptyProof, Key: 2}),
+		test.WithInvalidAssignment(&nonMembershipCircuit{Proof: forgedEmpty, Key: 1 + 1<<testDepth}),
+		test.WithInvalidAssignment(&nonMembershipCircuit{Proof: occupiedProof, Key: 1}),
+		test.WithCurves(ecc.BN254))
+}
+
+type upsertCircuit struct {
+	Proof    UpsertProof
+	Key      frontend.Variable
+	NewValue frontend.Variable
+}
+
+func (c *upsertCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.Proof.VerifyUpsert(api, &h, c.Key, c.NewValue)
+	return nil
+}
+
+func TestUpsert(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newTestTree(assert)
+	circuit := upsertCircuit{Proof: UpsertProof{Path: make([]frontend.Variable, testDepth)}}
+
+	insertProof, err := tree.Upsert(b(5), b(50))
+	assert.NoError(err)
+	updateProof, err := tree.Upsert(b(2), b(21))
+	assert.NoError(err)
+	assert.Equal(0, updateProof.IsInsert)
+	_, err = tree.Upsert(b(2+1<<testDepth), b(1))
+	assert.ErrorIs(err, ErrSlotOccupied)
+	v, ok, err := tree.Get(b(2))
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(int64(21), new(big.Int).SetBytes(v).Int64())
+
+	// claiming that the updated key was absent
+	forgedInsert := updateProof
+	forgedInsert.IsInsert = 1
+	assert.CheckCircuit(&circuit,
+		test.WithValidAssignment(&upsertCircuit{Proof: insertProof, Key: 5, NewValue: 50}),
+		test.WithValidAssignment(&upsertCircuit{Proof: updateProof, Key: 2, NewValue: 21}),
+		test.WithInvalidAssignment(&upsertCircuit{Proof: insertProof, Key: 5, NewValue: 51}),
+		test.WithInvalidAssignment(&upsertCircuit{Proof: forgedInsert, Key: 2, NewValue: 21}),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,227 @@
+package sparsemerkle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// ErrSlotOccupied is returned by [Tree.Upsert] when the slot of the key is
+// occupied by a different key.
+var ErrSlotOccupied = errors.New("slot occupied by a different key")
+
+type entry struct {
+	key   []byte
+	value []byte
+}
+
+// Tree is a native sparse Merkle tree of fixed depth which builds the
+// witnesses for [MembershipProof], [NonMembershipProof] and [UpsertProof].
+//
+// The native hash function must be the counterpart of the in-circuit hash
+// function (for example MiMC from gnark-crypto and
+// [github.com/consensys/gnark/std/hash/mimc]). The keys and values are
+// big-endian encodings of field elements of at most h.BlockSize() bytes.
+type Tree struct {
+	h     hash.Hash
+	depth int
+	// entries maps the slot to the stored key and value
+	entries map[string]entry
+	// nodes[0] are the leaf hashes and nodes[depth] contains the root. Only
+	// non-empty nodes are stored.
+	nodes []map[string][]byte
+	// empty[i] is the root of an empty subtree of depth i
+	empty [][]byte
+}
+
+// NewTree returns a new empty sparse Merkle tree of the given depth using the
+// native hash function h. A key is stored at the slot given by its depth least
+// significant bits, so keys sharing these bits can not be stored together. Use
+// a depth at least the bit size of the keys, or hash the keys first.
+func NewTree(h hash.Hash, depth int) (*Tree, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("invalid depth %d", depth)
+	}
+	t := &Tree{
+		h:       h,
+		depth:   depth,
+		entries: make(map[string]entry),
+		nodes:   make([]map[string][]byte, depth+1),
+		empty:   make([][]byte, depth+1),
+	}
+	for i := range t.nodes {
+		t.nodes[i] = make(map[string][]byte)
+	}
+	t.empty[0] = make([]byte, h.Size())
+	for i := 1; i <= depth; i++ {
+		t.empty[i] = t.nodeSum(t.empty[i-1], t.empty[i-1])
+	}
+	return t, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// Root returns the current root of the tree.
+func (t *Tree) Root() []byte {
+	return t.node(t.depth, new(big.Int))
+}
+
+// Get returns the value of key. It returns false if the key is not in the
+// tree.
+func (t *Tree) Get(key []byte) ([]byte, bool, error) {
+	key, err := t.pad(key)
+	if err != nil {
+		return nil, false, err
+	}
+	e, ok := t.entries[string(t.slot(key).Bytes())]
+	if !ok || !bytes.Equal(e.key, key) {
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+// ProveMembership returns the witness for [MembershipProof.VerifyMembership]
+// for key. It returns an error if the key is not in the tree.
+func (t *Tree) ProveMembership(key []byte) (MembershipProof, error) {
+	if _, ok, err := t.Get(key); err != nil {
+		return MembershipProof{}, err
+	} else if !ok {
+		return MembershipProof{}, errors.New("key not in tree")
+	}
+	key, _ = t.pad(key)
+	return MembershipProof{
+		Root: t.Root(),
+		Path: t.path(t.slot(key)),
+	}, nil
+}
+
+// ProveNonMembership returns the witness for
+// [NonMembershipProof.VerifyNonMembership] for key. It returns an error if the
+// key is in the tree.
+func (t *Tree) ProveNonMembership(key []byte) (NonMembershipProof, error) {
+	key, err := t.pad(key)
+	if err != nil {
+		return NonMembershipProof{}, err
+	}
+	slot := t.slot(key)
+	res := NonMembershipProof{
+		Root:       t.Root(),
+		IsEmpty:    1,
+		OtherKey:   0,
+		OtherValue: 0,
+		Path:       t.path(slot),
+	}
+	if e, ok := t.entries[string(slot.Bytes())]; ok {
+		if bytes.Equal(e.key, key) {
+			return NonMembershipProof{}, errors.New("key in tree")
+		}
+		res.IsEmpty = 0
+		res.OtherKey = e.key
+		res.OtherValue = e.value
+	}
+	return res, nil
+}
+
+// Upsert sets the value of key and returns the witness for
+// [UpsertProof.VerifyUpsert]. It returns [ErrSlotOccupied] if the slot of the
+// key is occupied by a different key, i.e. a key with the same depth least
+// significant bits.
+func (t *Tree) Upsert(key, value []byte) (UpsertProof, error) {
+	key, err := t.pad(key)
+	if err != nil {
+		return UpsertProof{}, err
+	}
+	if value, err = t.pad(value); err != nil {
+		return UpsertProof{}, err
+	}
+	slot := t.slot(key)
+	sk := string(slot.Bytes())
+	res := UpsertProof{
+		OldRoot:  t.Root(),
+		IsInsert: 1,
+		OldValue: 0,
+		Path:     t.path(slot),
+	}
+	if e, ok := t.entries[sk]; ok {
+		if !bytes.Equal(e.key, key) {
+			return UpsertProof{}, ErrSlotOccupied
+		}
+		res.IsInsert = 0
+		res.OldValue = e.value
+	}
+	t.entries[sk] = entry{key: key, value: value}
+
+	sum := t.leafSum(key, value)
+	idx := new(big.Int).Set(slot)
+	t.nodes[0][string(idx.Bytes())] = sum
+	for i := 0; i < t.depth; i++ {
+		sibling := res.Path[i].([]byte)
+		if idx.Bit(0) == 0 {
+			sum = t.nodeSum(sum, sibling)
+		} else {
+			sum = t.nodeSum(sibling, sum)
+		}
+		idx.Rsh(idx, 1)
+		t.nodes[i+1][string(idx.Bytes())] = sum
+	}
+	res.NewRoot = t.Root()
+	return res, nil
+}
+
+// pad left-pads the input to h.BlockSize() bytes.
+func (t *Tree) pad(in []byte) ([]byte, error) {
+	if len(in) > t.h.BlockSize() {
+		return nil, fmt.Errorf("input too long: %d > %d", len(in), t.h.BlockSize())
+	}
+	res := make([]byte, t.h.BlockSize())
+	copy(res[len(res)-len(in):], in)
+	return res, nil
+}
+
+// slot returns the index of the slot of the key.
+func (t *Tree) slot(key []byte) *big.Int {
+	k := new(big.Int).SetBytes(key)
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(t.depth))
+	mask.Sub(mask, big.NewInt(1))
+	return k.And(k, mask)
+}
+
+func (t *Tree) path(slot *big.Int) []frontend.Variable {
+	res := make([]frontend.Variable, t.depth)
+	idx := new(big.Int).Set(slot)
+	sibling := new(big.Int)
+	for i := 0; i < t.depth; i++ {
+		sibling.SetBit(idx, 0, idx.Bit(0)^1)
+		res[i] = t.node(i, sibling)
+		idx.Rsh(idx, 1)
+	}
+	return res
+}
+
+func (t *Tree) node(level int, index *big.Int) []byte {
+	if n, ok := t.nodes[level][string(index.Bytes())]; ok {
+		return n
+	}
+	return t.empty[level]
+}
+
+func (t *Tree) leafSum(key, value []byte) []byte {
+	t.h.Reset()
+	t.h.Write(key)
+	t.h.Write(value)
+	return t.h.Sum(nil)
+}
+
+func (t *Tree) nodeSum(a, b []byte) []byte {
+	t.h.Reset()
+	t.h.Write(a)
+	t.h.Write(b)
+	return t.h.Sum(nil)
+}
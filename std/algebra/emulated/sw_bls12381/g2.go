@@ -1,6 +1,7 @@
 package sw_bls12381
 
 import (
+	"fmt"
 	"math/big"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
@@ -10,6 +11,9 @@ import (
 )
 
 type G2 struct {
+	api frontend.API
+	fp  *emulated.Field[BaseField]
+	fr  *emulated.Field[ScalarField]
 	*fields_bls12381.Ext2
 	u1, w *emulated.Element[BaseField]
 	v     *fields_bls12381.E2
@@ -39,6 +43,14 @@ func newG2AffP(v bls12381.G2Affine) g2AffP {
 }
 
 func NewG2(api frontend.API) *G2 {
+	fp, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	fr, err := emulated.NewField[ScalarField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new scalar field: %v", err))
+	}
 	w := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
 	u1 := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939437")
 	v := fields_bls12381.E2{
@@ -46,6 +58,9 @@ func NewG2(api frontend.API) *G2 {
 		A1: emulated.ValueOf[BaseField]("1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257"),
 	}
 	return &G2{
+		api:  api,
+		fp:   fp,
+		fr:   fr,
 		Ext2: fields_bls12381.NewExt2(api),
 		w:    &w,
 		u1:   &u1,
@@ -265,3 +280,159 @@ func (g2 *G2) AssertIsEqual(p, q *G2Affine) {
 	g2.Ext2.AssertIsEqual(&p.P.X, &q.P.X)
 	g2.Ext2.AssertIsEqual(&p.P.Y, &q.P.Y)
 }
+
+// AddUnified adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be (0,0).
+// (0,0) is not on the twist but we conventionally take it as the
+// neutral/infinity point as per the [EVM].
+//
+// It uses the unified formulas of Brier and Joye ([[BriJoy02]] (Corollary 1)).
+// They don't apply when p.y + q.y = 0 and p.x ≠ q.x, which happens on the twist
+// for q = -φ(p) where φ(x, y) = (ωx, y) and ω is a cube root of unity. In that
+// case the chord formula is used.
+//
+// [BriJoy02]: https://link.springer.com/content/pdf/10.1007/3-540-45664-3_24.pdf
+// [EVM]: https://ethereum.github.io/yellowpaper/paper.pdf
+func (g2 *G2) AddUnified(p, q *G2Affine) *G2Affine {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := g2.api.And(g2.Ext2.IsZero(&p.P.X), g2.Ext2.IsZero(&p.P.Y))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := g2.api.And(g2.Ext2.IsZero(&q.P.X), g2.Ext2.IsZero(&q.P.Y))
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	pxqx := g2.Ext2.Mul(&p.P.X, &q.P.X)
+	pxplusqx := g2.Ext2.Add(&p.P.X, &q.P.X)
+	num := g2.Ext2.Square(pxplusqx)
+	num = g2.Ext2.Sub(num, pxqx)
+	denum := g2.Ext2.Add(&p.P.Y, &q.P.Y)
+	// selector3 = 1 when p.y + q.y = 0 and 0 otherwise
+	selector3 := g2.Ext2.IsZero(denum)
+	// selector4 = 1 when p.x = q.x and 0 otherwise
+	qxpx := g2.Ext2.Sub(&q.P.X, &p.P.X)
+	selector4 := g2.Ext2.IsZero(qxpx)
+	// if p.y + q.y = 0 and p.x ≠ q.x, use the chord λ = (q.y - p.y)/(q.x - p.x)
+	num = g2.Ext2.Select(selector3, g2.Ext2.Sub(&q.P.Y, &p.P.Y), num)
+	// if p.y + q.y = 0 and p.x = q.x, assign dummy 1 to denum and continue
+	denum = g2.Ext2.Select(selector3, g2.Ext2.Select(selector4, g2.Ext2.One(), qxpx), denum)
+	λ := g2.Ext2.DivUnchecked(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := g2.Ext2.Square(λ)
+	xr = g2.Ext2.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := g2.Ext2.Sub(&p.P.X, xr)
+	yr = g2.Ext2.Mul(yr, λ)
+	yr = g2.Ext2.Sub(yr, &p.P.Y)
+	result := &G2Affine{
+		P: g2AffP{X: *xr, Y: *yr},
+	}
+
+	zero := g2.Ext2.Zero()
+	infinity := &G2Affine{
+		P: g2AffP{X: *zero, Y: *zero},
+	}
+	// if p=(0,0) return q
+	result = g2.Select(selector1, q, result)
+	// if q=(0,0) return p
+	result = g2.Select(selector2, p, result)
+	// if p.y + q.y = 0 and p.x = q.x, return (0, 0)
+	result = g2.Select(g2.api.And(selector3, selector4), infinity, result)
+
+	return result
+}
+
+// Select selects between p and q given the selector b. If b == 1, then returns
+// p and q otherwise. The line precomputations are not carried over.
+func (g2 *G2) Select(b frontend.Variable, p, q *G2Affine) *G2Affine {
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Select(b, &p.P.X, &q.P.X),
+			Y: *g2.Ext2.Select(b, &p.P.Y, &q.P.Y),
+		},
+	}
+}
+
+// ScalarMul computes [s]p and returns it. It doesn't modify p nor s. This
+// function doesn't check that p is on the twist.
+//
+// ✅ p can be (0,0) and s can be zero. The method uses the unified addition
+// [G2.AddUnified] for every step and is thus complete, but costly.
+func (g2 *G2) ScalarMul(p *G2Affine, s *Scalar) *G2Affine {
+	sBits := g2.fr.ToBits(g2.fr.Reduce(s))
+	zero := g2.Ext2.Zero()
+	infinity := &G2Affine{
+		P: g2AffP{X: *zero, Y: *zero},
+	}
+	// start from the most significant bit, so that the constant (0,0) is never
+	// doubled
+	res := g2.Select(sBits[len(sBits)-1], p, infinity)
+	for i := len(sBits) - 2; i >= 0; i-- {
+		res = g2.AddUnified(res, res)
+		res = g2.Select(sBits[i], g2.AddUnified(res, p), res)
+	}
+	return res
+}
+
+// MultiScalarMul computes ∑ᵢ [sᵢ]pᵢ and returns it. It doesn't modify the
+// points nor the scalars. This function doesn't check that the points are on
+// the twist. It returns an error if the slices have different lengths.
+//
+// ✅ the points can be (0,0) and the scalars can be zero. As [G2.ScalarMul],
+// the method uses the unified addition for every step, but the doublings are
+// shared between all the points (Straus). The points are processed by pairs
+// with a lookup in {0, p₀, p₁, p₀+p₁}, so that every bit of the scalars costs
+// one doubling and one addition per pair of points instead of two additions
+// per point.
+func (g2 *G2) MultiScalarMul(p []*G2Affine, s []*Scalar) (*G2Affine, error) {
+	if len(p) != len(s) {
+		return nil, fmt.Errorf("mismatching points and scalars slice lengths")
+	}
+	zero := g2.Ext2.Zero()
+	infinity := &G2Affine{
+		P: g2AffP{X: *zero, Y: *zero},
+	}
+	if len(p) == 0 {
+		return infinity, nil
+	}
+	sBits := make([][]frontend.Variable, len(s))
+	for i := range s {
+		sBits[i] = g2.fr.ToBits(g2.fr.Reduce(s[i]))
+	}
+	// sums[j] = p[2j] + p[2j+1]
+	sums := make([]*G2Affine, len(p)/2)
+	for j := range sums {
+		sums[j] = g2.AddUnified(p[2*j], p[2*j+1])
+	}
+	// res is nil until the first addition, so that the constant (0,0) is never
+	// doubled
+	var res *G2Affine
+	add := func(q *G2Affine) {
+		if res == nil {
+			res = q
+		} else {
+			res = g2.AddUnified(res, q)
+		}
+	}
+	for i := len(sBits[0]) - 1; i >= 0; i-- {
+		if res != nil {
+			res = g2.AddUnified(res, res)
+		}
+		for j := range sums {
+			b0, b1 := sBits[2*j][i], sBits[2*j+1][i]
+			q := &G2Affine{
+				P: g2AffP{
+					X: *g2.Ext2.Lookup2(b0, b1, &infinity.P.X, &p[2*j].P.X, &p[2*j+1].P.X, &sums[j].P.X),
+					Y: *g2.Ext2.Lookup2(b0, b1, &infinity.P.Y, &p[2*j].P.Y, &p[2*j+1].P.Y, &sums[j].P.Y),
+				},
+			}
+			add(q)
+		}
+		if len(p)%2 == 1 {
+			n := len(p) - 1
+			add(g2.Select(sBits[n][i], p[n], infinity))
+		}
+	}
+	return res, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/test"
 )
@@ -118,3 +120,134 @@ func TestScalarMulG2BySeedTestSolve(t *testing.T) {
 	err := test.IsSolved(&scalarMulG2BySeedCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type addUnifiedG2Circuit struct {
+	In1, In2 G2Affine
+	Res      G2Affine
+}
+
+func (c *addUnifiedG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.AddUnified(&c.In1, &c.In2)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestAddUnifiedG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	_, in2 := randomG1G2Affines()
+	var neg, zero bls12381.G2Affine
+	neg.Neg(&in1)
+	// -φ(in1) = (ω·x, -y) where ω is a cube root of unity, in1 and -φ(in1)
+	// have opposite y coordinates but distinct x coordinates.
+	var omega fp.Element
+	omega.SetString("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
+	negPhi := neg
+	negPhi.X.MulByElement(&negPhi.X, &omega)
+	for _, c := range []struct {
+		name     string
+		in1, in2 bls12381.G2Affine
+	}{
+		{"distinct", in1, in2},
+		{"double", in1, in1},
+		{"opposite", in1, neg},
+		{"opposite y", in1, negPhi},
+		{"left zero", zero, in2},
+		{"right zero", in1, zero},
+		{"both zero", zero, zero},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var res bls12381.G2Affine
+			res.Add(&c.in1, &c.in2)
+			witness := addUnifiedG2Circuit{
+				In1: NewG2Affine(c.in1),
+				In2: NewG2Affine(c.in2),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&addUnifiedG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
+
+type scalarMulG2Circuit struct {
+	In  G2Affine
+	S   Scalar
+	Res G2Affine
+}
+
+func (c *scalarMulG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.ScalarMul(&c.In, &c.S)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestScalarMulG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in := randomG1G2Affines()
+	var s fr_bls12381.Element
+	s.SetRandom()
+	var res bls12381.G2Affine
+	res.ScalarMultiplication(&in, s.BigInt(new(big.Int)))
+	witness := scalarMulG2Circuit{
+		In:  NewG2Affine(in),
+		S:   NewScalar(s),
+		Res: NewG2Affine(res),
+	}
+	err := test.IsSolved(&scalarMulG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type multiScalarMulG2Circuit struct {
+	In  [3]G2Affine
+	S   [3]Scalar
+	Res G2Affine
+}
+
+func (c *multiScalarMulG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.MultiScalarMul([]*G2Affine{&c.In[0], &c.In[1], &c.In[2]}, []*Scalar{&c.S[0], &c.S[1], &c.S[2]})
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMultiScalarMulG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var in [3]bls12381.G2Affine
+	var s [3]fr_bls12381.Element
+	for i := range in {
+		_, in[i] = randomG1G2Affines()
+		s[i].SetRandom()
+	}
+	for _, c := range []struct {
+		name string
+		edit func(in *[3]bls12381.G2Affine, s *[3]fr_bls12381.Element)
+	}{
+		{"random", func(*[3]bls12381.G2Affine, *[3]fr_bls12381.Element) {}},
+		{"infinity", func(in *[3]bls12381.G2Affine, _ *[3]fr_bls12381.Element) { in[1].X.SetZero(); in[1].Y.SetZero() }},
+		{"zero scalar", func(_ *[3]bls12381.G2Affine, s *[3]fr_bls12381.Element) { s[2].SetZero() }},
+		{"opposite", func(in *[3]bls12381.G2Affine, s *[3]fr_bls12381.Element) { in[1].Neg(&in[0]); s[1] = s[0] }},
+	} {
+		in, s := in, s
+		c.edit(&in, &s)
+		var witness multiScalarMulG2Circuit
+		var res bls12381.G2Affine
+		if _, err := res.MultiExp(in[:], s[:], ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		for i := range in {
+			witness.In[i] = NewG2Affine(in[i])
+			witness.S[i] = NewScalar(s[i])
+		}
+		witness.Res = NewG2Affine(res)
+		assert.Run(func(assert *test.Assert) {
+			err := test.IsSolved(&multiScalarMulG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
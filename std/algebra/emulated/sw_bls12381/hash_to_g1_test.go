@@ -0,0 +1,48 @@
+package sw_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+	dst []byte
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res, err := g1.HashToG1(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g1.curve.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	res, err := bls12381.HashToG1(msg, dst)
+	assert.NoError(err)
+	circuit := hashToG1Circuit{
+		Msg: make([]uints.U8, len(msg)),
+		dst: dst,
+	}
+	witness := hashToG1Circuit{
+		Msg: uints.NewU8Array(msg),
+		Res: NewG1Affine(res),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,33 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// HashToG2 hashes the message msg to a point in G2 with the domain separation
+// tag dst following the BLS12381G2_XMD:SHA-256_SSWU_RO_ suite of [RFC 9380].
+// It corresponds to HashToG2 in gnark-crypto.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) HashToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := tofield.Emulated[BaseField](g2.api, msg, dst, 4)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0, err := g2.MapToCurve2(&fields_bls12381.E2{A0: *u[0], A1: *u[1]})
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	q1, err := g2.MapToCurve2(&fields_bls12381.E2{A0: *u[2], A1: *u[3]})
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	q0 = g2.isogeny(q0)
+	q1 = g2.isogeny(q1)
+	res := g2.AddUnified(q0, q1)
+	return g2.ClearCofactor(res), nil
+}
@@ -0,0 +1,45 @@
+package sw_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type hashToG2Circuit struct {
+	Msg []uints.U8
+	Res G2Affine
+	dst []byte
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.HashToG2(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	res, err := bls12381.HashToG2(msg, dst)
+	assert.NoError(err)
+	circuit := hashToG2Circuit{
+		Msg: make([]uints.U8, len(msg)),
+		dst: dst,
+	}
+	witness := hashToG2Circuit{
+		Msg: uints.NewU8Array(msg),
+		Res: NewG2Affine(res),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
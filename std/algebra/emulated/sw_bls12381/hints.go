@@ -0,0 +1,96 @@
+package sw_bls12381
+
+import (
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{g1IsSquareHint, g1SqrtHint, g2IsSquareHint, g2SqrtHint}
+}
+
+func g1IsSquareHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 || len(outputs) != 1 {
+			return fmt.Errorf("expecting one input and one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() >= 0 {
+			outputs[0].SetUint64(1)
+		} else {
+			outputs[0].SetUint64(0)
+		}
+		return nil
+	})
+}
+
+func g1SqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	// returns √x if x is a square and √(Z·x) otherwise
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 || len(outputs) != 1 {
+			return fmt.Errorf("expecting one input and one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() < 0 {
+			x.Mul(&x, new(fp.Element).SetUint64(g1SSWUZ))
+		}
+		if x.Sqrt(&x) == nil {
+			return fmt.Errorf("no square root")
+		}
+		x.BigInt(outputs[0])
+		return nil
+	})
+}
+
+func g2IsSquareHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 || len(outputs) != 1 {
+			return fmt.Errorf("expecting two inputs and one output")
+		}
+		var x bls12381.E2
+		x.A0.SetBigInt(inputs[0])
+		x.A1.SetBigInt(inputs[1])
+		if x.Legendre() >= 0 {
+			outputs[0].SetUint64(1)
+		} else {
+			outputs[0].SetUint64(0)
+		}
+		return nil
+	})
+}
+
+func g2SqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	// returns √x if x is a square and √(Z·x) otherwise
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 || len(outputs) != 2 {
+			return fmt.Errorf("expecting two inputs and two outputs")
+		}
+		var x, z bls12381.E2
+		x.A0.SetBigInt(inputs[0])
+		x.A1.SetBigInt(inputs[1])
+		if x.Legendre() < 0 {
+			z.A0.SetString(g2SSWUZ[0])
+			z.A1.SetString(g2SSWUZ[1])
+			x.Mul(&x, &z)
+		}
+		if x.Legendre() < 0 {
+			return fmt.Errorf("no square root")
+		}
+		x.Sqrt(&x)
+		x.A0.BigInt(outputs[0])
+		x.A1.BigInt(outputs[1])
+		return nil
+	})
+}
@@ -0,0 +1,213 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// constants of the simplified SWU map to the isogenous twist E₂': y² = x³ + A'x + B'
+var (
+	g2SSWUA = [2]string{"0", "240"}
+	g2SSWUB = [2]string{"1012", "1012"}
+	// Z = -(2+u)
+	g2SSWUZ = [2]string{
+		"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559785",
+		"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786",
+	}
+)
+
+// MapToG2 maps the element u of 𝔽p² to a point in G2 following the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ map_to_curve and clear_cofactor steps of
+// [RFC 9380]. It corresponds to MapToG2 in gnark-crypto and to the
+// MAP_FP2_TO_G2 operation of EIP-2537. The point at infinity is returned as
+// (0,0).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) MapToG2(u *fields_bls12381.E2) (*G2Affine, error) {
+	res, err := g2.MapToCurve2(u)
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	res = g2.isogeny(res)
+	return g2.ClearCofactor(res), nil
+}
+
+// MapToCurve2 implements the simplified Shallue-van de Woestijne-Ulas map to
+// the twist E₂' 3-isogenous to BLS12-381 G2 as in gnark-crypto.
+func (g2 *G2) MapToCurve2(u *fields_bls12381.E2) (*G2Affine, error) {
+	sswuA := g2.constE2(g2SSWUA)
+	sswuB := g2.constE2(g2SSWUB)
+	sswuZ := g2.constE2(g2SSWUZ)
+
+	// tv1 = Z·u², tv2 = tv1² + tv1
+	tv1 := g2.Ext2.Mul(sswuZ, g2.Ext2.Square(u))
+	tv2 := g2.Ext2.Add(g2.Ext2.Square(tv1), tv1)
+	// x₁ = B'(tv2 + 1) / A'·CMOV(Z, -tv2, tv2 ≠ 0). The denominator is never
+	// zero as A' and Z are non-zero.
+	tv3 := g2.Ext2.Mul(sswuB, g2.Ext2.Add(tv2, g2.Ext2.One()))
+	tv2IsZero := g2.Ext2.IsZero(tv2)
+	tv4 := g2.Ext2.Select(tv2IsZero, sswuZ, g2.Ext2.Neg(tv2))
+	tv4 = g2.Ext2.Mul(sswuA, tv4)
+	x1 := g2.Ext2.DivUnchecked(tv3, tv4)
+	// gx₁ = x₁³ + A'x₁ + B'
+	gx1 := g2.Ext2.Square(x1)
+	gx1 = g2.Ext2.Add(gx1, sswuA)
+	gx1 = g2.Ext2.Mul(gx1, x1)
+	gx1 = g2.Ext2.Add(gx1, sswuB)
+
+	// the hints return whether gx₁ is a square and y₁ such that y₁² = gx₁ if
+	// it is a square and y₁² = Z·gx₁ otherwise. As Z is not a square, exactly
+	// one of the cases holds except when gx₁ = 0 which is a square.
+	isQR, err := g2.fp.NewHintWithNativeOutput(g2IsSquareHint, 1, &gx1.A0, &gx1.A1)
+	if err != nil {
+		return nil, fmt.Errorf("is square hint: %w", err)
+	}
+	y1s, err := g2.fp.NewHint(g2SqrtHint, 2, &gx1.A0, &gx1.A1)
+	if err != nil {
+		return nil, fmt.Errorf("sqrt hint: %w", err)
+	}
+	y1 := &fields_bls12381.E2{A0: *y1s[0], A1: *y1s[1]}
+	g2.api.AssertIsBoolean(isQR[0])
+	g2.api.AssertIsEqual(g2.api.Mul(g2.Ext2.IsZero(gx1), g2.api.Sub(1, isQR[0])), 0)
+	g2.Ext2.AssertIsEqual(
+		g2.Ext2.Square(y1),
+		g2.Ext2.Select(isQR[0], gx1, g2.Ext2.Mul(sswuZ, gx1)),
+	)
+
+	// if gx₁ is not a square, then x₂ = tv1·x₁ and y₂ = tv1·u·y₁.
+	x := g2.Ext2.Select(isQR[0], x1, g2.Ext2.Mul(tv1, x1))
+	y := g2.Ext2.Select(isQR[0], y1, g2.Ext2.Mul(g2.Ext2.Mul(tv1, u), y1))
+
+	// fix the sign of y such that sgn0(u) == sgn0(y)
+	flip := g2.api.Xor(g2.sgn0(u), g2.sgn0(y))
+	y = g2.Ext2.Select(flip, g2.Ext2.Neg(y), y)
+
+	return &G2Affine{
+		P: g2AffP{X: *x, Y: *y},
+	}, nil
+}
+
+// ClearCofactor maps a point of the twist E₂ to G2 by multiplying it by the
+// effective cofactor using the method of Budroni and Pintore as in
+// gnark-crypto:
+//
+//	[x₀²-x₀-1]p + ψ([x₀-1]p) + ψ²([2]p)
+//
+// The point at infinity is represented as (0,0) and the method uses complete
+// arithmetic.
+func (g2 *G2) ClearCofactor(p *G2Affine) *G2Affine {
+	// x₀ is negative
+	xp := g2.neg(g2.scalarMulBySeedUnified(p))
+	xxp := g2.neg(g2.scalarMulBySeedUnified(xp))
+	negP := g2.neg(p)
+
+	res := g2.AddUnified(xxp, g2.neg(xp))
+	res = g2.AddUnified(res, negP)
+	t := g2.psi(g2.AddUnified(xp, negP))
+	res = g2.AddUnified(res, t)
+	// -ψ²([2]p) = (w·x, y) for [2]p = (x, y)
+	t = g2.AddUnified(p, p)
+	t = &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.MulByElement(&t.P.X, g2.w),
+			Y: t.P.Y,
+		},
+	}
+	return g2.AddUnified(res, g2.neg(t))
+}
+
+// scalarMulBySeedUnified computes [|x₀|]p using complete arithmetic.
+func (g2 *G2) scalarMulBySeedUnified(p *G2Affine) *G2Affine {
+	res := p
+	for i := 62; i >= 0; i-- {
+		res = g2.AddUnified(res, res)
+		if (seed>>i)&1 == 1 {
+			res = g2.AddUnified(res, p)
+		}
+	}
+	return res
+}
+
+// isogeny maps the point p on E₂' to E₂. The kernel points of the isogeny are
+// mapped to (0,0).
+func (g2 *G2) isogeny(p *G2Affine) *G2Affine {
+	xNum := g2.evalPolynomial(false, g2IsogenyXNumerator, &p.P.X)
+	xDen := g2.evalPolynomial(true, g2IsogenyXDenominator, &p.P.X)
+	yNum := g2.evalPolynomial(false, g2IsogenyYNumerator, &p.P.X)
+	yNum = g2.Ext2.Mul(yNum, &p.P.Y)
+	yDen := g2.evalPolynomial(true, g2IsogenyYDenominator, &p.P.X)
+
+	// the denominators vanish simultaneously exactly on the kernel
+	isInf := g2.Ext2.IsZero(xDen)
+	one, zero := g2.Ext2.One(), g2.Ext2.Zero()
+	xDen = g2.Ext2.Select(isInf, one, xDen)
+	yDen = g2.Ext2.Select(isInf, one, yDen)
+	x := g2.Ext2.DivUnchecked(xNum, xDen)
+	y := g2.Ext2.DivUnchecked(yNum, yDen)
+
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Select(isInf, zero, x),
+			Y: *g2.Ext2.Select(isInf, zero, y),
+		},
+	}
+}
+
+// evalPolynomial evaluates the polynomial with the given coefficients in
+// increasing degree at x using Horner's method. If monic is set, the leading
+// coefficient 1 is implicit.
+func (g2 *G2) evalPolynomial(monic bool, coefficients [][2]string, x *fields_bls12381.E2) *fields_bls12381.E2 {
+	res := g2.constE2(coefficients[len(coefficients)-1])
+	if monic {
+		res = g2.Ext2.Add(res, x)
+	}
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		res = g2.Ext2.Mul(res, x)
+		res = g2.Ext2.Add(res, g2.constE2(coefficients[i]))
+	}
+	return res
+}
+
+// sgn0 returns sgn0(a₀) ∨ (a₀ == 0 ∧ sgn0(a₁)) for z = a₀ + a₁u.
+func (g2 *G2) sgn0(z *fields_bls12381.E2) frontend.Variable {
+	sign0 := sgn0(g2.fp, &z.A0)
+	zero0 := g2.fp.IsZero(&z.A0)
+	sign1 := sgn0(g2.fp, &z.A1)
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+func (g2 *G2) constE2(c [2]string) *fields_bls12381.E2 {
+	return &fields_bls12381.E2{
+		A0: emulated.ValueOf[BaseField](c[0]),
+		A1: emulated.ValueOf[BaseField](c[1]),
+	}
+}
+
+// coefficients of the 3-isogeny from E₂' to E₂, in increasing degree. The
+// denominators are monic and the leading coefficient is omitted.
+var (
+	g2IsogenyXNumerator = [][2]string{
+		{"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542"},
+		{"0", "2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261"},
+		{"3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033", "0"},
+	}
+	g2IsogenyXDenominator = [][2]string{
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715"},
+		{"12", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775"},
+	}
+	g2IsogenyYNumerator = [][2]string{
+		{"3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558", "3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558"},
+		{"0", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263"},
+		{"2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776", "0"},
+	}
+	g2IsogenyYDenominator = [][2]string{
+		{"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355"},
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571"},
+		{"18", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769"},
+	}
+)
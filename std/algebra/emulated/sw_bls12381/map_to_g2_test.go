@@ -0,0 +1,49 @@
+package sw_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToG2Circuit struct {
+	U   fields_bls12381.E2
+	Res G2Affine
+}
+
+func (c *mapToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.MapToG2(&c.U)
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u bls12381.E2
+	u.SetRandom()
+	for _, c := range []struct {
+		name string
+		u    bls12381.E2
+	}{
+		{"random", u},
+		{"zero", bls12381.E2{}},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			res := bls12381.MapToG2(c.u)
+			witness := mapToG2Circuit{
+				U:   fields_bls12381.FromE2(&c.u),
+				Res: NewG2Affine(res),
+			}
+			err := test.IsSolved(&mapToG2Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
@@ -1,8 +1,11 @@
 package sw_bn254
 
 import (
+	"fmt"
+
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/math/emulated"
 )
@@ -23,6 +26,30 @@ func NewG1Affine(v bn254.G1Affine) G1Affine {
 	}
 }
 
+// G1 implements the hash-to-curve methods for G1.
+type G1 struct {
+	api    frontend.API
+	curveF *emulated.Field[BaseField]
+	curve  *sw_emulated.Curve[BaseField, ScalarField]
+}
+
+// NewG1 returns a new instance of G1.
+func NewG1(api frontend.API) (*G1, error) {
+	ba, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	curve, err := sw_emulated.New[BaseField, ScalarField](api, sw_emulated.GetBN254Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	return &G1{
+		api:    api,
+		curveF: ba,
+		curve:  curve,
+	}, nil
+}
+
 // NewScalar allocates a witness from the native scalar and returns it.
 func NewScalar(v fr_bn254.Element) Scalar {
 	return emulated.ValueOf[ScalarField](v)
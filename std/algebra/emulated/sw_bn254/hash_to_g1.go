@@ -0,0 +1,29 @@
+package sw_bn254
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// HashToG1 hashes the message msg to a point in G1 with the domain separation
+// tag dst following the BN254G1_XMD:SHA-256_SVDW_RO_ suite of [RFC 9380]. It
+// corresponds to HashToG1 in gnark-crypto.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) HashToG1(msg []uints.U8, dst []byte) (*G1Affine, error) {
+	u, err := tofield.Emulated[BaseField](g1.api, msg, dst, 2)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0, err := g1.MapToG1(u[0])
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	q1, err := g1.MapToG1(u[1])
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	return g1.curve.AddUnified(q0, q1), nil
+}
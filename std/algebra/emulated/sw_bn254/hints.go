@@ -0,0 +1,54 @@
+package sw_bn254
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{g1IsSquareHint, g1SqrtHint}
+}
+
+func g1IsSquareHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 || len(outputs) != 1 {
+			return fmt.Errorf("expecting one input and one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() >= 0 {
+			outputs[0].SetUint64(1)
+		} else {
+			outputs[0].SetUint64(0)
+		}
+		return nil
+	})
+}
+
+func g1SqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	// returns √x if x is a square and √(-x) otherwise
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 || len(outputs) != 1 {
+			return fmt.Errorf("expecting one input and one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() < 0 {
+			x.Neg(&x)
+		}
+		if x.Sqrt(&x) == nil {
+			return fmt.Errorf("no square root")
+		}
+		x.BigInt(outputs[0])
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package sw_bn254
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// constants of the Shallue-van de Woestijne map to BN254 G1: y² = x³ + 3
+const (
+	g1SVDWZ  = 1
+	g1SVDWC1 = 4
+	// c₂ = -Z/2
+	g1SVDWC2 = "10944121435919637611123202872628637544348155578648911831344518947322613104291"
+	// c₃ = √(-g(Z)·3Z²)
+	g1SVDWC3 = "8815841940592487685674414971303048083897117035520822607866"
+	// c₄ = -4g(Z)/3Z²
+	g1SVDWC4 = "7296080957279758407415468581752425029565437052432607887563012631548408736189"
+	g1B      = 3
+)
+
+// MapToG1 maps the field element u to a point in G1 following the
+// BN254G1_XMD:SHA-256_SVDW_RO_ map_to_curve step of [RFC 9380]. It
+// corresponds to MapToG1 in gnark-crypto. As the cofactor of G1 is one, there
+// is no cofactor clearing step.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) MapToG1(u *emulated.Element[BaseField]) (*G1Affine, error) {
+	one := g1.curveF.One()
+	c2 := g1.curveF.NewElement(g1SVDWC2)
+	c3 := g1.curveF.NewElement(g1SVDWC3)
+	c4 := g1.curveF.NewElement(g1SVDWC4)
+
+	// tv1 = u²·c₁, tv2 = 1 + tv1, tv1 = 1 - tv1
+	tv1 := g1.curveF.MulConst(g1.curveF.Mul(u, u), big.NewInt(g1SVDWC1))
+	tv2 := g1.curveF.Add(one, tv1)
+	tv1 = g1.curveF.Sub(one, tv1)
+	// tv3 = inv0(tv1·tv2)
+	tv3 := g1.curveF.Mul(tv1, tv2)
+	tv3IsZero := g1.curveF.IsZero(tv3)
+	tv3 = g1.curveF.Select(tv3IsZero, g1.curveF.Zero(),
+		g1.curveF.Inverse(g1.curveF.Select(tv3IsZero, one, tv3)))
+	// tv4 = u·tv1·tv3·c₃
+	tv4 := g1.curveF.Mul(g1.curveF.Mul(u, tv1), g1.curveF.Mul(tv3, c3))
+
+	x1 := g1.curveF.Sub(c2, tv4)
+	x2 := g1.curveF.Add(c2, tv4)
+	// x₃ = (tv2²·tv3)²·c₄ + Z
+	x3 := g1.curveF.Mul(g1.curveF.Mul(tv2, tv2), tv3)
+	x3 = g1.curveF.Mul(g1.curveF.Mul(x3, x3), c4)
+	x3 = g1.curveF.Add(x3, g1.curveF.NewElement(g1SVDWZ))
+
+	// x = x₁ if g(x₁) is a square, x₂ if g(x₂) is a square and x₃ otherwise
+	e1, err := g1.isSquare(g1.g(x1))
+	if err != nil {
+		return nil, err
+	}
+	e2, err := g1.isSquare(g1.g(x2))
+	if err != nil {
+		return nil, err
+	}
+	x := g1.curveF.Select(e1, x1, g1.curveF.Select(e2, x2, x3))
+
+	// y = √g(x). By the construction of the map, g(x) is always a square and
+	// the constraint ensures it.
+	gx := g1.g(x)
+	y, err := g1.curveF.NewHint(g1SqrtHint, 1, gx)
+	if err != nil {
+		return nil, fmt.Errorf("sqrt hint: %w", err)
+	}
+	g1.curveF.AssertIsEqual(g1.curveF.Mul(y[0], y[0]), gx)
+
+	// fix the sign of y such that sgn0(u) == sgn0(y)
+	flip := g1.api.Xor(g1.sgn0(u), g1.sgn0(y[0]))
+	res := g1.curveF.Select(flip, g1.curveF.Neg(y[0]), y[0])
+
+	return &G1Affine{X: *x, Y: *res}, nil
+}
+
+// g returns x³ + b.
+func (g1 *G1) g(x *emulated.Element[BaseField]) *emulated.Element[BaseField] {
+	res := g1.curveF.Mul(g1.curveF.Mul(x, x), x)
+	return g1.curveF.Add(res, g1.curveF.NewElement(g1B))
+}
+
+// isSquare returns 1 if x is a square and 0 otherwise. The hint returns the
+// flag and r such that r² = x if x is a square and r² = -x otherwise. As
+// p ≡ 3 mod 4, -1 is not a square and exactly one of the cases holds except
+// when x = 0 which is a square.
+func (g1 *G1) isSquare(x *emulated.Element[BaseField]) (frontend.Variable, error) {
+	isQR, err := g1.curveF.NewHintWithNativeOutput(g1IsSquareHint, 1, x)
+	if err != nil {
+		return nil, fmt.Errorf("is square hint: %w", err)
+	}
+	r, err := g1.curveF.NewHint(g1SqrtHint, 1, x)
+	if err != nil {
+		return nil, fmt.Errorf("sqrt hint: %w", err)
+	}
+	g1.api.AssertIsBoolean(isQR[0])
+	g1.api.AssertIsEqual(g1.api.Mul(g1.curveF.IsZero(x), g1.api.Sub(1, isQR[0])), 0)
+	g1.curveF.AssertIsEqual(
+		g1.curveF.Mul(r[0], r[0]),
+		g1.curveF.Select(isQR[0], x, g1.curveF.Neg(x)),
+	)
+	return isQR[0], nil
+}
+
+// sgn0 returns the parity of the canonical representation of z.
+func (g1 *G1) sgn0(z *emulated.Element[BaseField]) frontend.Variable {
+	z = g1.curveF.Reduce(z)
+	g1.curveF.AssertIsInRange(z)
+	return g1.curveF.ToBits(z)[0]
+}
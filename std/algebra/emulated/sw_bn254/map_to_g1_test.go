@@ -0,0 +1,57 @@
+package sw_bn254
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToG1Circuit struct {
+	U   emulated.Element[BaseField]
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res, err := g1.MapToG1(&c.U)
+	if err != nil {
+		return err
+	}
+	g1.curve.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u, half fp.Element
+	u.SetRandom()
+	half.SetUint64(2)
+	half.Inverse(&half)
+	for _, c := range []struct {
+		name string
+		u    fp.Element
+	}{
+		{"random", u},
+		{"zero", fp.Element{}},
+		// 1-u²c₁ = 0 for u = 1/2, exercising inv0(0)
+		{"half", half},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			res := bn254.MapToG1(c.u)
+			witness := mapToG1Circuit{
+				U:   emulated.ValueOf[BaseField](c.u),
+				Res: NewG1Affine(res),
+			}
+			err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package te_emulated implements elliptic curve group operations in twisted
+Edwards form.
+
+The elliptic curve is the set of points (X,Y) satisfying the equation:
+
+	aX² + Y² = 1 + dX²Y²
+
+over some base field 𝐅p for some constants a, d ∈ 𝐅p. The neutral element is
+the point (0,1). Additionally, for every curve we also define its generator
+(base point) G. All these parameters are stored in the variable of type
+[CurveParams].
+
+This package uses the unified addition formulas, which are complete when a is a
+square and d is a non-square in 𝐅p. This is the case for the provided curve
+parameters, so that the point additions and doublings do not have exceptional
+cases.
+
+The package provides the curve parameters for Ed25519, see [GetEd25519Params].
+
+This package uses field emulation (unlike package
+[github.com/consensys/gnark/std/algebra/native/twistededwards], which requires
+the base field of the curve to be the native field). This allows to use any
+curve over any native (SNARK) field. The drawback of this approach is the
+extreme cost of the operations.
+*/
+package te_emulated
@@ -0,0 +1,65 @@
+package te_emulated
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// CurveParams defines parameters of an elliptic curve in twisted Edwards form
+// given by the equation
+//
+//	aX² + Y² = 1 + dX²Y²
+//
+// The base point is defined by (Gx, Gy).
+type CurveParams struct {
+	A        *big.Int      // a in curve equation
+	D        *big.Int      // d in curve equation
+	Gx       *big.Int      // base point x
+	Gy       *big.Int      // base point y
+	Gm       [][2]*big.Int // m*base point coords
+	Cofactor *big.Int      // cofactor of the curve
+}
+
+// GetEd25519Params returns the curve parameters for the curve Ed25519 as
+// defined in RFC 8032. When initialising new curve, use the base field
+// [emulated.Ed25519Fp] and scalar field [emulated.Ed25519Fr].
+func GetEd25519Params() CurveParams {
+	var fp emulated.Ed25519Fp
+	p := fp.Modulus()
+	// a = -1
+	a := new(big.Int).Sub(p, big.NewInt(1))
+	// d = -121665/121666
+	d := new(big.Int).ModInverse(big.NewInt(121666), p)
+	d.Mul(d, big.NewInt(-121665))
+	d.Mod(d, p)
+	gx, _ := new(big.Int).SetString("15112221349535400772501151409588531511454012693041857206046113283949847762202", 10)
+	gy, _ := new(big.Int).SetString("46316835694926478169428394003475163141307993866256225615783033603165251855960", 10)
+	return CurveParams{
+		A:        a,
+		D:        d,
+		Gx:       gx,
+		Gy:       gy,
+		Gm:       computeTable(p, a, d, gx, gy, 253),
+		Cofactor: big.NewInt(8),
+	}
+}
+
+// GetCurveParams returns suitable curve parameters given the parametric type
+// Base as base field. It caches the parameters and modifying the values in the
+// parameters struct leads to undefined behaviour.
+func GetCurveParams[Base emulated.FieldParams]() CurveParams {
+	var t Base
+	switch t.Modulus().String() {
+	case emulated.Ed25519Fp{}.Modulus().String():
+		return ed25519Params
+	default:
+		panic("no stored parameters")
+	}
+}
+
+var ed25519Params CurveParams
+
+func init() {
+	ed25519Params = GetEd25519Params()
+}
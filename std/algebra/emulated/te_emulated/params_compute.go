@@ -0,0 +1,37 @@
+package te_emulated
+
+import "math/big"
+
+// computeTable returns the points [2ⁱ]G for i < n on the twisted Edwards curve
+// with coefficients a and d over the prime field of order p.
+func computeTable(p, a, d, gx, gy *big.Int, n int) [][2]*big.Int {
+	table := make([][2]*big.Int, n)
+	table[0] = [2]*big.Int{new(big.Int).Set(gx), new(big.Int).Set(gy)}
+	for i := 1; i < n; i++ {
+		table[i] = addPoints(p, a, d, table[i-1], table[i-1])
+	}
+	return table
+}
+
+// addPoints returns the sum of the points p1 and p2 using the unified addition
+// formulas.
+func addPoints(p, a, d *big.Int, p1, p2 [2]*big.Int) [2]*big.Int {
+	x1x2 := new(big.Int).Mul(p1[0], p2[0])
+	y1y2 := new(big.Int).Mul(p1[1], p2[1])
+	x1y2 := new(big.Int).Mul(p1[0], p2[1])
+	y1x2 := new(big.Int).Mul(p1[1], p2[0])
+	dxy := new(big.Int).Mul(x1x2, y1y2)
+	dxy.Mul(dxy, d).Mod(dxy, p)
+	// x₃ = (x₁y₂ + y₁x₂) / (1 + dx₁x₂y₁y₂)
+	num := new(big.Int).Add(x1y2, y1x2)
+	den := new(big.Int).Add(big.NewInt(1), dxy)
+	den.ModInverse(den, p)
+	x := num.Mul(num, den).Mod(num, p)
+	// y₃ = (y₁y₂ - ax₁x₂) / (1 - dx₁x₂y₁y₂)
+	num = new(big.Int).Mul(a, x1x2)
+	num.Sub(y1y2, num)
+	den = new(big.Int).Sub(big.NewInt(1), dxy)
+	den.Mod(den, p).ModInverse(den, p)
+	y := num.Mul(num, den).Mod(num, p)
+	return [2]*big.Int{x, y}
+}
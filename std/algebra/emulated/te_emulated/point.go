@@ -0,0 +1,277 @@
+package te_emulated
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// New returns a new [Curve] instance over the base field Base and scalar field
+// Scalars defined by the curve parameters params. It returns an error if
+// initialising the field emulation fails (for example, when the native field is
+// too small).
+func New[Base, Scalars emulated.FieldParams](api frontend.API, params CurveParams) (*Curve[Base, Scalars], error) {
+	ba, err := emulated.NewField[Base](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	sa, err := emulated.NewField[Scalars](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar api: %w", err)
+	}
+	emuGm := make([]AffinePoint[Base], len(params.Gm))
+	for i, v := range params.Gm {
+		emuGm[i] = AffinePoint[Base]{emulated.ValueOf[Base](v[0]), emulated.ValueOf[Base](v[1])}
+	}
+	var fp Base
+	minusOne := new(big.Int).Sub(fp.Modulus(), big.NewInt(1))
+	return &Curve[Base, Scalars]{
+		params:    params,
+		api:       api,
+		baseApi:   ba,
+		scalarApi: sa,
+		g: AffinePoint[Base]{
+			X: emulated.ValueOf[Base](params.Gx),
+			Y: emulated.ValueOf[Base](params.Gy),
+		},
+		gm:          emuGm,
+		a:           emulated.ValueOf[Base](params.A),
+		d:           emulated.ValueOf[Base](params.D),
+		aIsMinusOne: new(big.Int).Mod(params.A, fp.Modulus()).Cmp(minusOne) == 0,
+	}, nil
+}
+
+// Curve is an initialised curve which allows performing group operations.
+type Curve[Base, Scalars emulated.FieldParams] struct {
+	// params is the parameters of the curve
+	params CurveParams
+	// api is the native api, we construct it ourselves to be sure
+	api frontend.API
+	// baseApi is the api for point operations
+	baseApi *emulated.Field[Base]
+	// scalarApi is the api for scalar operations
+	scalarApi *emulated.Field[Scalars]
+
+	// g is the generator (base point) of the curve.
+	g AffinePoint[Base]
+
+	// gm are the pre-computed doubles the generator (base point) of the curve.
+	gm []AffinePoint[Base]
+
+	a           emulated.Element[Base]
+	d           emulated.Element[Base]
+	aIsMinusOne bool
+}
+
+// Generator returns the base point of the curve. The method does not copy and
+// modifying the returned element leads to undefined behaviour!
+func (c *Curve[B, S]) Generator() *AffinePoint[B] {
+	return &c.g
+}
+
+// GeneratorMultiples returns the pre-computed doubles [2ⁱ]G of the base point
+// of the curve. The method does not copy and modifying the returned element
+// leads to undefined behaviour!
+func (c *Curve[B, S]) GeneratorMultiples() []AffinePoint[B] {
+	return c.gm
+}
+
+// AffinePoint represents a point on the elliptic curve. We do not check that
+// the point is actually on the curve.
+//
+// Point (0,1) represents the neutral element.
+type AffinePoint[Base emulated.FieldParams] struct {
+	X, Y emulated.Element[Base]
+}
+
+// Neutral returns the neutral element (0,1) of the curve.
+func (c *Curve[B, S]) Neutral() *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Zero(),
+		Y: *c.baseApi.One(),
+	}
+}
+
+// Neg returns an inverse of p. It doesn't modify p.
+func (c *Curve[B, S]) Neg(p *AffinePoint[B]) *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Neg(&p.X),
+		Y: p.Y,
+	}
+}
+
+// AssertIsEqual asserts that p and q are the same point.
+func (c *Curve[B, S]) AssertIsEqual(p, q *AffinePoint[B]) {
+	c.baseApi.AssertIsEqual(&p.X, &q.X)
+	c.baseApi.AssertIsEqual(&p.Y, &q.Y)
+}
+
+// AssertIsOnCurve asserts if p belongs to the curve. It doesn't modify p.
+func (c *Curve[B, S]) AssertIsOnCurve(p *AffinePoint[B]) {
+	// aX² + Y² == 1 + dX²Y²
+	xx := c.baseApi.Mul(&p.X, &p.X)
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	left := c.baseApi.Add(c.mulA(xx), yy)
+	right := c.baseApi.Add(c.baseApi.One(), c.baseApi.Mul(&c.d, c.baseApi.Mul(xx, yy)))
+	c.baseApi.AssertIsEqual(left, right)
+}
+
+// Add adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be the neutral element.
+//
+// It uses the unified addition formulas
+//
+//	x₃ = (x₁y₂ + y₁x₂) / (1 + dx₁x₂y₁y₂)
+//	y₃ = (y₁y₂ - ax₁x₂) / (1 - dx₁x₂y₁y₂)
+//
+// which are complete for points on the curve when a is a square and d is a
+// non-square.
+func (c *Curve[B, S]) Add(p, q *AffinePoint[B]) *AffinePoint[B] {
+	x1x2 := c.baseApi.Mul(&p.X, &q.X)
+	y1y2 := c.baseApi.Mul(&p.Y, &q.Y)
+	// x₁y₂ + y₁x₂ = (x₁ + y₁)(x₂ + y₂) - x₁x₂ - y₁y₂
+	xy := c.baseApi.Mul(c.baseApi.Add(&p.X, &p.Y), c.baseApi.Add(&q.X, &q.Y))
+	xy = c.baseApi.Sub(xy, c.baseApi.Add(x1x2, y1y2))
+	dxy := c.baseApi.Mul(&c.d, c.baseApi.Mul(x1x2, y1y2))
+
+	x := c.baseApi.Div(xy, c.baseApi.Add(c.baseApi.One(), dxy))
+	y := c.baseApi.Div(c.baseApi.Sub(y1y2, c.mulA(x1x2)), c.baseApi.Sub(c.baseApi.One(), dxy))
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Double doubles p and returns it. It doesn't modify p.
+//
+// ⚠️  p must be on the curve.
+//
+// It uses the dedicated doubling formulas
+//
+//	x₃ = 2xy / (ax² + y²)
+//	y₃ = (y² - ax²) / (2 - ax² - y²)
+//
+// which follow from the unified addition formulas by substituting the curve
+// equation.
+func (c *Curve[B, S]) Double(p *AffinePoint[B]) *AffinePoint[B] {
+	xx := c.baseApi.Mul(&p.X, &p.X)
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	axx := c.mulA(xx)
+	den := c.baseApi.Add(axx, yy)
+	xy := c.baseApi.Mul(&p.X, &p.Y)
+
+	x := c.baseApi.Div(c.baseApi.Add(xy, xy), den)
+	y := c.baseApi.Div(c.baseApi.Sub(yy, axx), c.baseApi.Sub(c.baseApi.NewElement(2), den))
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// mulA returns ax.
+func (c *Curve[B, S]) mulA(x *emulated.Element[B]) *emulated.Element[B] {
+	if c.aIsMinusOne {
+		return c.baseApi.Neg(x)
+	}
+	return c.baseApi.Mul(&c.a, x)
+}
+
+// Select selects between p and q given the selector b. If b == 1, then returns
+// p and q otherwise.
+func (c *Curve[B, S]) Select(b frontend.Variable, p, q *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Select(b, &p.X, &q.X)
+	y := c.baseApi.Select(b, &p.Y, &q.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Lookup2 performs a 2-bit lookup between i0, i1, i2, i3 based on bits b0
+// and b1. Returns:
+//   - i0 if b0=0 and b1=0,
+//   - i1 if b0=1 and b1=0,
+//   - i2 if b0=0 and b1=1,
+//   - i3 if b0=1 and b1=1.
+func (c *Curve[B, S]) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Lookup2(b0, b1, &i0.X, &i1.X, &i2.X, &i3.X)
+	y := c.baseApi.Lookup2(b0, b1, &i0.Y, &i1.Y, &i2.Y, &i3.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// scalarBits returns the bits of the canonical representation of s. The number
+// of bits is the bit length of the scalar field modulus.
+func (c *Curve[B, S]) scalarBits(s *emulated.Element[S]) []frontend.Variable {
+	var st S
+	sr := c.scalarApi.Reduce(s)
+	// the points may have a component outside of the prime order subgroup, in
+	// which case the result depends on the representative of the scalar.
+	c.scalarApi.AssertIsInRange(sr)
+	return c.scalarApi.ToBits(sr)[:st.Modulus().BitLen()]
+}
+
+// ScalarMul computes [s]p and returns it. It doesn't modify p nor s.
+// This function doesn't check that the p is on the curve. See AssertIsOnCurve.
+//
+// ✅ p can be the neutral element and s can be 0.
+//
+// It computes the left-to-right double-and-add algorithm using the complete
+// formulas, so there are no exceptional cases.
+func (c *Curve[B, S]) ScalarMul(p *AffinePoint[B], s *emulated.Element[S]) *AffinePoint[B] {
+	sBits := c.scalarBits(s)
+	n := len(sBits)
+	res := c.Select(sBits[n-1], p, c.Neutral())
+	for i := n - 2; i >= 0; i-- {
+		res = c.Double(res)
+		res = c.Select(sBits[i], c.Add(res, p), res)
+	}
+	return res
+}
+
+// ScalarMulBase computes [s]g and returns it where g is the fixed curve
+// generator. It doesn't modify s.
+//
+// ✅ s can be 0.
+//
+// It computes the right-to-left fixed-base double-and-add algorithm with the
+// points [2ⁱ]g precomputed.
+func (c *Curve[B, S]) ScalarMulBase(s *emulated.Element[S]) *AffinePoint[B] {
+	sBits := c.scalarBits(s)
+	gm := c.GeneratorMultiples()
+	if len(gm) < len(sBits) {
+		return c.ScalarMul(c.Generator(), s)
+	}
+	res := c.Select(sBits[0], c.Generator(), c.Neutral())
+	for i := 1; i < len(sBits); i++ {
+		res = c.Select(sBits[i], c.Add(res, &gm[i]), res)
+	}
+	return res
+}
+
+// JointScalarMulBase computes [s1]g + [s2]p and returns it, where g is the
+// fixed generator. It doesn't modify p, s1 and s2.
+//
+// ✅ p can be the neutral element and s1 and s2 can be 0.
+//
+// It uses the Straus-Shamir trick with the doublings shared between the two
+// scalar multiplications.
+func (c *Curve[B, S]) JointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Element[S]) *AffinePoint[B] {
+	s1Bits := c.scalarBits(s1)
+	s2Bits := c.scalarBits(s2)
+	n := len(s1Bits)
+	g := c.Generator()
+	gp := c.Add(g, p)
+	neutral := c.Neutral()
+	res := c.Lookup2(s1Bits[n-1], s2Bits[n-1], neutral, g, p, gp)
+	for i := n - 2; i >= 0; i-- {
+		res = c.Double(res)
+		res = c.Add(res, c.Lookup2(s1Bits[i], s2Bits[i], neutral, g, p, gp))
+	}
+	return res
+}
@@ -0,0 +1,191 @@
+package te_emulated
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+var testCurve = ecc.BN254
+
+type (
+	fp = emulated.Ed25519Fp
+	fr = emulated.Ed25519Fr
+)
+
+func pointOf(p [2]*big.Int) AffinePoint[fp] {
+	return AffinePoint[fp]{
+		X: emulated.ValueOf[fp](p[0]),
+		Y: emulated.ValueOf[fp](p[1]),
+	}
+}
+
+// scalarMul computes [s]P out-circuit.
+func scalarMul(params CurveParams, P [2]*big.Int, s *big.Int) [2]*big.Int {
+	var f fp
+	res := [2]*big.Int{big.NewInt(0), big.NewInt(1)}
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res = addPoints(f.Modulus(), params.A, params.D, res, res)
+		if s.Bit(i) == 1 {
+			res = addPoints(f.Modulus(), params.A, params.D, res, P)
+		}
+	}
+	return res
+}
+
+// decodePoint decodes the RFC 8032 encoding of a point out-circuit.
+func decodePoint(t *testing.T, params CurveParams, buf []byte) [2]*big.Int {
+	var f fp
+	p := f.Modulus()
+	le := make([]byte, len(buf))
+	for i := range buf {
+		le[len(buf)-1-i] = buf[i]
+	}
+	y := new(big.Int).SetBytes(le)
+	sign := y.Bit(255)
+	y.SetBit(y, 255, 0)
+	// x² = (y² - 1) / (dy² - a)
+	yy := new(big.Int).Mul(y, y)
+	num := new(big.Int).Sub(yy, big.NewInt(1))
+	den := new(big.Int).Mul(params.D, yy)
+	den.Sub(den, params.A).Mod(den, p)
+	den.ModInverse(den, p)
+	xx := num.Mul(num, den).Mod(num, p)
+	x := new(big.Int).ModSqrt(xx, p)
+	if x == nil {
+		t.Fatal("invalid point")
+	}
+	if x.Bit(0) != sign {
+		x.Sub(p, x)
+	}
+	return [2]*big.Int{x, y}
+}
+
+type AddTest[T, S emulated.FieldParams] struct {
+	P, Q, Sum, Double AffinePoint[T]
+}
+
+func (c *AddTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsOnCurve(&c.P)
+	cr.AssertIsOnCurve(&c.Q)
+	cr.AssertIsEqual(cr.Add(&c.P, &c.Q), &c.Sum)
+	cr.AssertIsEqual(cr.Add(&c.P, &c.P), &c.Double)
+	cr.AssertIsEqual(cr.Double(&c.P), &c.Double)
+	cr.AssertIsEqual(cr.Add(&c.P, cr.Neutral()), &c.P)
+	cr.AssertIsEqual(cr.Add(&c.P, cr.Neg(&c.P)), cr.Neutral())
+	cr.AssertIsEqual(cr.Double(cr.Neutral()), cr.Neutral())
+	return nil
+}
+
+func TestAdd(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	var f fp
+	G := [2]*big.Int{params.Gx, params.Gy}
+	a, err := rand.Int(rand.Reader, emulated.Ed25519Fr{}.Modulus())
+	assert.NoError(err)
+	b, err := rand.Int(rand.Reader, emulated.Ed25519Fr{}.Modulus())
+	assert.NoError(err)
+	P := scalarMul(params, G, a)
+	Q := scalarMul(params, G, b)
+	witness := AddTest[fp, fr]{
+		P:      pointOf(P),
+		Q:      pointOf(Q),
+		Sum:    pointOf(scalarMul(params, G, new(big.Int).Add(a, b))),
+		Double: pointOf(addPoints(f.Modulus(), params.A, params.D, P, P)),
+	}
+	err = test.IsSolved(&AddTest[fp, fr]{}, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type ScalarMulBaseTest[T, S emulated.FieldParams] struct {
+	S        emulated.Element[S]
+	Expected AffinePoint[T]
+}
+
+func (c *ScalarMulBaseTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsEqual(cr.ScalarMulBase(&c.S), &c.Expected)
+	return nil
+}
+
+func TestScalarMulBase(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	// the public key of Ed25519 is [a]G for the clamped hash of the seed
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	h := sha512.Sum512(sk.Seed())
+	h[0] &= 248
+	h[31] &= 127
+	h[31] |= 64
+	le := make([]byte, 32)
+	for i := range le {
+		le[i] = h[31-i]
+	}
+	s := new(big.Int).SetBytes(le)
+	s.Mod(s, emulated.Ed25519Fr{}.Modulus())
+	witness := ScalarMulBaseTest[fp, fr]{
+		S:        emulated.ValueOf[fr](s),
+		Expected: pointOf(decodePoint(t, params, pk)),
+	}
+	err = test.IsSolved(&ScalarMulBaseTest[fp, fr]{}, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type ScalarMulTest[T, S emulated.FieldParams] struct {
+	P        AffinePoint[T]
+	S1, S2   emulated.Element[S]
+	Expected AffinePoint[T]
+}
+
+func (c *ScalarMulTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	// [s1]G + [s2]P
+	res1 := cr.JointScalarMulBase(&c.P, &c.S2, &c.S1)
+	res2 := cr.Add(cr.ScalarMulBase(&c.S1), cr.ScalarMul(&c.P, &c.S2))
+	cr.AssertIsEqual(res1, &c.Expected)
+	cr.AssertIsEqual(res2, &c.Expected)
+	return nil
+}
+
+func TestScalarMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	G := [2]*big.Int{params.Gx, params.Gy}
+	order := emulated.Ed25519Fr{}.Modulus()
+	s1, err := rand.Int(rand.Reader, order)
+	assert.NoError(err)
+	s2, err := rand.Int(rand.Reader, order)
+	assert.NoError(err)
+	k, err := rand.Int(rand.Reader, order)
+	assert.NoError(err)
+	P := scalarMul(params, G, k)
+	e := new(big.Int).Mul(s2, k)
+	e.Add(e, s1).Mod(e, order)
+	witness := ScalarMulTest[fp, fr]{
+		P:        pointOf(P),
+		S1:       emulated.ValueOf[fr](s1),
+		S2:       emulated.ValueOf[fr](s2),
+		Expected: pointOf(scalarMul(params, G, e)),
+	}
+	err = test.IsSolved(&ScalarMulTest[fp, fr]{}, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
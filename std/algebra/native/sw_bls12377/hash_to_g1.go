@@ -0,0 +1,181 @@
+package sw_bls12377
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// constants of the simplified SWU map to the isogenous curve E': y² = x³ + A'x + B'
+const (
+	g1SSWUA = "258664426012969092796408009721202742408018065645352501567204841856062976176281513834280849065051431927238430294002"
+	g1SSWUB = 22
+	g1SSWUZ = 5
+)
+
+// xGen is the BLS12-377 seed x₀ = 9586122913090633729.
+const xGen uint64 = 9586122913090633729
+
+// HashToG1 hashes the message msg to a point in G1 with the domain separation
+// tag dst following the BLS12377G1_XMD:SHA-256_SSWU_RO_ suite of [RFC 9380].
+// It corresponds to HashToG1 in gnark-crypto. The method is defined over the
+// scalar field of BW6-761 which is the base field of BLS12-377.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func HashToG1(api frontend.API, msg []uints.U8, dst []byte) (*G1Affine, error) {
+	u, err := tofield.Native(api, msg, dst, 2)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0, err := MapToCurve1(api, u[0])
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	q1, err := MapToCurve1(api, u[1])
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	q0 = g1Isogeny(api, q0)
+	q1 = g1Isogeny(api, q1)
+	q0.AddUnified(api, *q1)
+	return ClearCofactor(api, q0), nil
+}
+
+// MapToG1 maps the field element u to a point in G1 following the
+// BLS12377G1_XMD:SHA-256_SSWU_RO_ map_to_curve and clear_cofactor steps of
+// [RFC 9380]. It corresponds to MapToG1 in gnark-crypto. The point at
+// infinity is returned as (0,0).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func MapToG1(api frontend.API, u frontend.Variable) (*G1Affine, error) {
+	res, err := MapToCurve1(api, u)
+	if err != nil {
+		return nil, fmt.Errorf("map to curve: %w", err)
+	}
+	res = g1Isogeny(api, res)
+	return ClearCofactor(api, res), nil
+}
+
+// MapToCurve1 implements the simplified Shallue-van de Woestijne-Ulas map to
+// the curve E' 2-isogenous to BLS12-377 G1 as in gnark-crypto.
+func MapToCurve1(api frontend.API, u frontend.Variable) (*G1Affine, error) {
+	// tv1 = Z·u², tv2 = tv1² + tv1
+	tv1 := api.Mul(g1SSWUZ, u, u)
+	tv2 := api.Add(api.Mul(tv1, tv1), tv1)
+	// x₁ = B'(tv2 + 1) / A'·CMOV(Z, -tv2, tv2 ≠ 0). The denominator is never
+	// zero as A' and Z are non-zero.
+	tv3 := api.Mul(g1SSWUB, api.Add(tv2, 1))
+	tv4 := api.Select(api.IsZero(tv2), g1SSWUZ, api.Neg(tv2))
+	tv4 = api.Mul(g1SSWUA, tv4)
+	x1 := api.DivUnchecked(tv3, tv4)
+	// gx₁ = x₁³ + A'x₁ + B'
+	gx1 := api.Mul(x1, x1)
+	gx1 = api.Add(gx1, g1SSWUA)
+	gx1 = api.Mul(gx1, x1)
+	gx1 = api.Add(gx1, g1SSWUB)
+
+	// the hint returns whether gx₁ is a square and y₁ such that y₁² = gx₁ if
+	// it is a square and y₁² = Z·gx₁ otherwise. As Z is not a square, exactly
+	// one of the cases holds except when gx₁ = 0 which is a square.
+	hint, err := api.NewHint(g1SqrtRatioHint, 2, gx1)
+	if err != nil {
+		return nil, fmt.Errorf("sqrt hint: %w", err)
+	}
+	isQR, y1 := hint[0], hint[1]
+	api.AssertIsBoolean(isQR)
+	api.AssertIsEqual(api.Mul(api.IsZero(gx1), api.Sub(1, isQR)), 0)
+	api.AssertIsEqual(api.Mul(y1, y1), api.Select(isQR, gx1, api.Mul(g1SSWUZ, gx1)))
+
+	// if gx₁ is not a square, then x₂ = tv1·x₁ and y₂ = tv1·u·y₁.
+	x := api.Select(isQR, x1, api.Mul(tv1, x1))
+	y := api.Select(isQR, y1, api.Mul(tv1, u, y1))
+
+	// fix the sign of y such that sgn0(u) == sgn0(y)
+	flip := api.Xor(g1Sgn0(api, u), g1Sgn0(api, y))
+	y = api.Select(flip, api.Neg(y), y)
+
+	return &G1Affine{X: x, Y: y}, nil
+}
+
+// ClearCofactor maps a point of E to G1 by multiplying it by the effective
+// cofactor 1-x₀. The point at infinity is represented as (0,0) and the method
+// uses complete arithmetic.
+func ClearCofactor(api frontend.API, p *G1Affine) *G1Affine {
+	res := &G1Affine{X: p.X, Y: p.Y}
+	for i := 62; i >= 0; i-- {
+		res.AddUnified(api, *res)
+		if (xGen>>i)&1 == 1 {
+			res.AddUnified(api, *p)
+		}
+	}
+	res.Neg(api, *res)
+	return res.AddUnified(api, *p)
+}
+
+// g1Isogeny maps the point p on E' to E. The kernel points of the isogeny are
+// mapped to (0,0).
+func g1Isogeny(api frontend.API, p *G1Affine) *G1Affine {
+	xNum := g1EvalPolynomial(api, false, g1IsogenyXNumerator, p.X)
+	xDen := g1EvalPolynomial(api, true, g1IsogenyXDenominator, p.X)
+	yNum := api.Mul(g1EvalPolynomial(api, false, g1IsogenyYNumerator, p.X), p.Y)
+	yDen := g1EvalPolynomial(api, true, g1IsogenyYDenominator, p.X)
+
+	// the denominators vanish simultaneously exactly on the kernel
+	isInf := api.IsZero(xDen)
+	xDen = api.Select(isInf, 1, xDen)
+	yDen = api.Select(isInf, 1, yDen)
+	x := api.DivUnchecked(xNum, xDen)
+	y := api.DivUnchecked(yNum, yDen)
+
+	return &G1Affine{
+		X: api.Select(isInf, 0, x),
+		Y: api.Select(isInf, 0, y),
+	}
+}
+
+// g1EvalPolynomial evaluates the polynomial with the given coefficients in
+// increasing degree at x using Horner's method. If monic is set, the leading
+// coefficient 1 is implicit.
+func g1EvalPolynomial(api frontend.API, monic bool, coefficients []string, x frontend.Variable) frontend.Variable {
+	var res frontend.Variable = coefficients[len(coefficients)-1]
+	if monic {
+		res = api.Add(res, x)
+	}
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		res = api.Mul(res, x)
+		res = api.Add(res, coefficients[i])
+	}
+	return res
+}
+
+// g1Sgn0 returns the parity of the canonical representation of z.
+func g1Sgn0(api frontend.API, z frontend.Variable) frontend.Variable {
+	// the default decomposition is enforced to be canonical
+	return api.ToBinary(z)[0]
+}
+
+// coefficients of the 2-isogeny from E' to E, in increasing degree. The
+// denominators are monic and the leading coefficient is omitted.
+var (
+	g1IsogenyXNumerator = []string{
+		"193998319509726820447277314072485610595876362210707887456279225959507476652652651634192264150953923683470146535424",
+		"40474824132456359704279181570318738632422647360355249739068643631356267969150730939906729705473",
+		"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093633",
+	}
+	g1IsogenyXDenominator = []string{
+		"161899296529825438817116726281274954529690589441420998956274574525425071876602923759626918821892",
+	}
+	g1IsogenyYNumerator = []string{
+		"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093631",
+		"32333053251621136903112182208573040583096119983059602439070460434672245065050016464457115901761911040205276577794",
+		"129332213006484547066038603046131306324615528732935438218576102373893108782773376834518846023512776472080255287298",
+		"226331372761347957259321141983031841844344323660550327972398729833380409804798219928097777122126690108885281275905",
+	}
+	g1IsogenyYDenominator = []string{
+		"258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458169",
+		"971395779178952632902700357687649727178143536648525993737647447152550431259617542557761512931340",
+		"485697889589476316451350178843824863589071768324262996868823723576275215629808771278880756465676",
+	}
+)
@@ -0,0 +1,80 @@
+package sw_bls12377
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToG1Circuit struct {
+	U   frontend.Variable
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	res, err := MapToG1(api, c.U)
+	if err != nil {
+		return err
+	}
+	res.AssertIsEqual(api, c.Res)
+	return nil
+}
+
+func TestMapToG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	for _, c := range []struct {
+		name string
+		u    fp.Element
+	}{
+		{"random", u},
+		{"zero", fp.Element{}},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			res := bls12377.MapToG1(c.u)
+			witness := mapToG1Circuit{U: c.u.String()}
+			witness.Res.Assign(&res)
+			err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+	dst []byte
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	res, err := HashToG1(api, c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	res.AssertIsEqual(api, c.Res)
+	return nil
+}
+
+func TestHashToG1(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-BLS12377G1_XMD:SHA-256_SSWU_RO_")
+	res, err := bls12377.HashToG1(msg, dst)
+	assert.NoError(err)
+	circuit := hashToG1Circuit{
+		Msg: make([]uints.U8, len(msg)),
+		dst: dst,
+	}
+	witness := hashToG1Circuit{
+		Msg: uints.NewU8Array(msg),
+	}
+	witness.Res.Assign(&res)
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+}
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/consensys/gnark/constraint/solver"
 )
 
@@ -13,6 +14,7 @@ func GetHints() []solver.Hint {
 		decomposeScalarG1,
 		decomposeScalarG1Simple,
 		decomposeScalarG2,
+		g1SqrtRatioHint,
 	}
 }
 
@@ -88,3 +90,26 @@ func decomposeScalarG2(scalarField *big.Int, inputs []*big.Int, outputs []*big.I
 
 	return nil
 }
+
+func g1SqrtRatioHint(scalarField *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	// returns whether x is a square and √x if x is a square and √(Z·x) otherwise
+	if len(inputs) != 1 {
+		return fmt.Errorf("expecting one input")
+	}
+	if len(outputs) != 2 {
+		return fmt.Errorf("expecting two outputs")
+	}
+	var x fp.Element
+	x.SetBigInt(inputs[0])
+	if x.Legendre() >= 0 {
+		outputs[0].SetUint64(1)
+	} else {
+		outputs[0].SetUint64(0)
+		x.Mul(&x, new(fp.Element).SetUint64(g1SSWUZ))
+	}
+	if x.Sqrt(&x) == nil {
+		return fmt.Errorf("no square root")
+	}
+	x.BigInt(outputs[1])
+	return nil
+}
@@ -1,3 +1,10 @@
+// Package fri implements the in-circuit verifier and the native prover of FRI
+// proofs of proximity, compatible with gnark-crypto.
+//
+// The blowup factor and the number of queries are configurable with
+// [WithLogBlowup] and [WithNbQueries]. The folding is radix-2 only: every step
+// halves the evaluation domain with x → x², and the verifier opens a single
+// fiber of two evaluations per step.
 package fri
 
 import (
@@ -13,10 +20,9 @@ import (
 	"github.com/consensys/gnark/std/accumulator/merkle"
 )
 
-// same constant as in gnark-crypto
-const rho = 8
-const logRho = 3
-const nbRounds = 1
+// same constants as in gnark-crypto, used by default
+const defaultLogRho = 3
+const defaultNbRounds = 1
 
 // Round a single round of interactions between prover and verifier for fri.
 type Round struct {
@@ -60,18 +66,41 @@ type RadixTwoFri struct {
 
 	// rootDomain generator of the cyclic group of unity of size \rho * size
 	genInv big.Int
+
+	// logRho logarithm of the blowup factor \rho
+	logRho int
+
+	// nbRounds number of queries of the verifier
+	nbRounds int
 }
 
 // NewRadixTwoFri creates an FFT-like oracle proof of proximity.
 // * h is the hash function that is used for the Merkle proofs
 // * gen is the generator of the cyclic group of unity of size \rho * size
-func NewRadixTwoFri(size uint64, h hash.FieldHasher, gen big.Int) RadixTwoFri {
+// * opts are the options for the blowup factor and the number of queries. They
+// must match the options given to the prover, see [NewProver].
+//
+// By default the blowup factor is 8 and the number of queries is 1 as in
+// gnark-crypto. It returns an error if the size is smaller than 2, if the
+// number of queries is 0 or if the blowup factor is out of range.
+func NewRadixTwoFri(size uint64, h hash.FieldHasher, gen big.Int, opts ...Option) (RadixTwoFri, error) {
 
 	var res RadixTwoFri
 
+	if size < 2 {
+		return res, fmt.Errorf("size %d is too small, at least 2 is required", size)
+	}
+
 	// computing the number of steps
 	n := ecc.NextPowerOfTwo(size)
 	nbSteps := bits.TrailingZeros(uint(n))
+
+	cfg := newConfig(opts...)
+	if err := cfg.check(nbSteps); err != nil {
+		return res, err
+	}
+	res.logRho = cfg.logRho
+	res.nbRounds = cfg.nbRounds
 	res.nbSteps = nbSteps
 	res.size = size
 
@@ -81,7 +110,7 @@ func NewRadixTwoFri(size uint64, h hash.FieldHasher, gen big.Int) RadixTwoFri {
 	// generator
 	res.genInv.Set(&gen)
 
-	return res
+	return res, nil
 }
 
 // verifyProofOfProximitySingleRound verifies the proof of proximity (see gnark-crypto).
@@ -129,8 +158,9 @@ func (s RadixTwoFri) verifyProofOfProximitySingleRound(api frontend.API, salt fr
 		return err
 	}
 	bin := api.ToBinary(binSeed)
-	bPos := api.FromBinary(bin[:logRho+s.nbSteps]...)
+	bPos := api.FromBinary(bin[:s.logRho+s.nbSteps]...)
 
+	rho := uint64(1) << s.logRho
 	si, err := api.NewHint(DeriveQueriesPositions, s.nbSteps, bPos, rho*s.size, s.nbSteps)
 	if err != nil {
 		return err
@@ -225,7 +255,10 @@ func (s RadixTwoFri) verifyProofOfProximitySingleRound(api frontend.API, salt fr
 // VerifyProofOfProximity verifies the proof, by checking each interaction one
 // by one.
 func (s RadixTwoFri) VerifyProofOfProximity(api frontend.API, proof ProofOfProximity) error {
-	for i := 0; i < nbRounds; i++ {
+	if len(proof.Rounds) != s.nbRounds {
+		return fmt.Errorf("expected %d rounds, got %d", s.nbRounds, len(proof.Rounds))
+	}
+	for i := 0; i < s.nbRounds; i++ {
 		err := s.verifyProofOfProximitySingleRound(api, i, proof.Rounds[i])
 		if err != nil {
 			return err
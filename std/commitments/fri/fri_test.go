@@ -35,7 +35,10 @@ func (p *ProofOfProximityTest) Define(api frontend.API) error {
 	gInv.SetString("14607982016670611764231825270871087984049314771307170893064215224383340934614", 10)
 
 	// oracle proof of proximity
-	opp := NewRadixTwoFri(sizePolyTest, &h, gInv)
+	opp, err := NewRadixTwoFri(sizePolyTest, &h, gInv)
+	if err != nil {
+		return err
+	}
 	//err = opp.verifyProofOfProximitySingleRound(api, p.Salt, p.Proof.Rounds[0])
 	err = opp.VerifyProofOfProximity(api, p.Proof)
 	if err != nil {
@@ -67,8 +70,8 @@ func TestFriVerification(t *testing.T) {
 
 	// 3 - create the circuit, allocate the slices...
 	var circuit ProofOfProximityTest
-	circuit.Proof.Rounds = make([]Round, nbRounds)
-	for i := 0; i < nbRounds; i++ {
+	circuit.Proof.Rounds = make([]Round, defaultNbRounds)
+	for i := 0; i < defaultNbRounds; i++ {
 		circuit.Proof.Rounds[i].Interactions = make([][2]merkle.MerkleProof, nbSteps)
 		for j := 0; j < nbSteps; j++ {
 
@@ -90,8 +93,8 @@ func TestFriVerification(t *testing.T) {
 
 	// 4 - populate the witness, allocate the slice first...
 	var witness ProofOfProximityTest
-	witness.Proof.Rounds = make([]Round, nbRounds)
-	for i := 0; i < nbRounds; i++ {
+	witness.Proof.Rounds = make([]Round, defaultNbRounds)
+	for i := 0; i < defaultNbRounds; i++ {
 		witness.Proof.Rounds[i].Evaluation = proximityProof.Rounds[i].Evaluation
 		witness.Proof.Rounds[i].Interactions = make([][2]merkle.MerkleProof, nbSteps)
 		for j := 0; j < nbSteps; j++ {
@@ -129,3 +132,76 @@ func TestFriVerification(t *testing.T) {
 	}
 
 }
+
+type friCircuit struct {
+	Proof  ProofOfProximity
+	size   uint64
+	genInv big.Int
+	opts   []Option
+}
+
+func (c *friCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	opp, err := NewRadixTwoFri(c.size, &h, c.genInv, c.opts...)
+	if err != nil {
+		return err
+	}
+	return opp.VerifyProofOfProximity(api, c.Proof)
+}
+
+func TestFriProver(t *testing.T) {
+	assert := test.NewAssert(t)
+	const size = 16
+	for _, opts := range [][]Option{
+		nil,
+		{WithLogBlowup(1), WithNbQueries(3)},
+	} {
+		prover, err := NewProver(ecc.BN254.ScalarField(), size, hash.MIMC_BN254.New(), opts...)
+		assert.NoError(err)
+
+		polynomial := make([]*big.Int, size)
+		for i := range polynomial {
+			var e fr.Element
+			e.SetRandom()
+			polynomial[i] = e.BigInt(new(big.Int))
+		}
+		proof, err := prover.BuildProofOfProximity(polynomial)
+		assert.NoError(err)
+
+		// the polynomial has too large degree
+		invalidPolynomial := append(polynomial, big.NewInt(1))
+		_, err = prover.BuildProofOfProximity(invalidPolynomial)
+		assert.Error(err)
+
+		circuit := friCircuit{Proof: PlaceholderProofOfProximity(size, opts...), size: size, genInv: prover.GeneratorInverse(), opts: opts}
+		assignment := friCircuit{Proof: proof}
+		invalid := friCircuit{Proof: PlaceholderProofOfProximity(size, opts...)}
+		for i := range proof.Rounds {
+			invalid.Proof.Rounds[i].Evaluation = new(big.Int).Add(proof.Rounds[i].Evaluation.(*big.Int), big.NewInt(1))
+			invalid.Proof.Rounds[i].Interactions = proof.Rounds[i].Interactions
+		}
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithInvalidAssignment(&invalid), test.WithCurves(ecc.BN254), test.NoProverChecks())
+	}
+}
+
+func TestFriInvalidOptions(t *testing.T) {
+	assert := test.NewAssert(t)
+	const size = 16
+	prover, err := NewProver(ecc.BN254.ScalarField(), size, hash.MIMC_BN254.New())
+	assert.NoError(err)
+	for _, opts := range [][]Option{
+		{WithNbQueries(0)},
+		{WithLogBlowup(0)},
+		{WithLogBlowup(60)},
+	} {
+		_, err := NewProver(ecc.BN254.ScalarField(), size, hash.MIMC_BN254.New(), opts...)
+		assert.Error(err)
+		_, err = NewRadixTwoFri(size, nil, prover.GeneratorInverse(), opts...)
+		assert.Error(err)
+	}
+	_, err = NewRadixTwoFri(1, nil, prover.GeneratorInverse())
+	assert.Error(err)
+}
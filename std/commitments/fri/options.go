@@ -0,0 +1,60 @@
+package fri
+
+import (
+	"errors"
+	"fmt"
+)
+
+type config struct {
+	logRho   int
+	nbRounds int
+}
+
+func newConfig(opts ...Option) config {
+	cfg := config{
+		logRho:   defaultLogRho,
+		nbRounds: defaultNbRounds,
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+	return cfg
+}
+
+// Option allows to modify the parameters of the proof of proximity. The same
+// options must be given to the prover and the verifier.
+type Option func(cfg *config)
+
+// WithLogBlowup sets the logarithm of the blowup factor ρ, i.e. the ratio
+// between the size of the evaluation domain and the size of the polynomial. A
+// larger blowup factor increases the soundness of every query, but also the
+// cost of the prover. Default is 3.
+func WithLogBlowup(logRho uint) Option {
+	return func(cfg *config) {
+		cfg.logRho = int(logRho)
+	}
+}
+
+// WithNbQueries sets the number of queries of the verifier. Every query is
+// performed in an independent round of interactions, so the size of the proof
+// and the cost of the verifier grow linearly with the number of queries.
+// Default is 1.
+func WithNbQueries(nbQueries uint) Option {
+	return func(cfg *config) {
+		cfg.nbRounds = int(nbQueries)
+	}
+}
+
+// check returns an error if the options are not valid for a polynomial folded
+// in nbSteps steps.
+func (cfg config) check(nbSteps int) error {
+	if cfg.nbRounds < 1 {
+		return errors.New("number of queries must be positive")
+	}
+	// the query positions are sampled on logRho+nbSteps bits and the size of
+	// the evaluation domain is computed on an uint64.
+	if cfg.logRho < 1 || cfg.logRho+nbSteps > 63 {
+		return fmt.Errorf("log blowup %d out of range [1, %d]", cfg.logRho, 63-nbSteps)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package fri
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"math/bits"
+
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+)
+
+// Prover is the native prover building proofs of proximity which can be
+// verified in-circuit with [RadixTwoFri.VerifyProofOfProximity].
+//
+// The prover follows the protocol of gnark-crypto, but is generic over the
+// field and supports custom blowup factor and number of queries. The output
+// of the prover can be assigned directly into the circuit witness.
+type Prover struct {
+
+	// hash function that is used for Fiat Shamir and for committing to
+	// the oracles. It must be the native counterpart of the hash function
+	// given to the verifier.
+	h hash.Hash
+
+	// modulus of the field
+	modulus *big.Int
+
+	// nbSteps number of interactions between the prover and the verifier
+	nbSteps int
+
+	// size of the polynomial
+	size uint64
+
+	// logRho logarithm of the blowup factor
+	logRho int
+
+	// nbRounds number of queries of the verifier
+	nbRounds int
+
+	// gen generator of the cyclic group of unity of size \rho * size
+	gen big.Int
+
+	// genInv inverse of gen
+	genInv big.Int
+}
+
+// NewProver returns a new prover for polynomials of the given size over the
+// field defined by modulus. The size must be a power of two. The hash function
+// h must be the native counterpart of the hash function used in-circuit. The
+// options must match the options given to [NewRadixTwoFri].
+func NewProver(modulus *big.Int, size uint64, h hash.Hash, opts ...Option) (*Prover, error) {
+	if size < 2 || size&(size-1) != 0 {
+		return nil, fmt.Errorf("size %d is not a power of two", size)
+	}
+	cfg := newConfig(opts...)
+	if err := cfg.check(bits.TrailingZeros64(size)); err != nil {
+		return nil, err
+	}
+	res := &Prover{
+		h:        h,
+		modulus:  new(big.Int).Set(modulus),
+		nbSteps:  bits.TrailingZeros64(size),
+		size:     size,
+		logRho:   cfg.logRho,
+		nbRounds: cfg.nbRounds,
+	}
+	if err := res.initGenerator(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GeneratorInverse returns the inverse of the generator of the evaluation
+// domain. It is given to [NewRadixTwoFri] for verifying the proofs.
+func (s *Prover) GeneratorInverse() big.Int {
+	var res big.Int
+	res.Set(&s.genInv)
+	return res
+}
+
+// BuildProofOfProximity generates a proof that the evaluations of the
+// polynomial p, given in canonical basis, on the evaluation domain is close to
+// a polynomial of degree less than the size of the prover.
+func (s *Prover) BuildProofOfProximity(p []*big.Int) (ProofOfProximity, error) {
+	if uint64(len(p)) > s.size {
+		return ProofOfProximity{}, fmt.Errorf("polynomial of size %d is larger than %d", len(p), s.size)
+	}
+	evals := s.evaluate(p)
+
+	var proof ProofOfProximity
+	proof.Rounds = make([]Round, s.nbRounds)
+	for i := 0; i < s.nbRounds; i++ {
+		round, err := s.buildProofOfProximitySingleRound(big.NewInt(int64(i)), evals)
+		if err != nil {
+			return ProofOfProximity{}, err
+		}
+		proof.Rounds[i] = round
+	}
+	return proof, nil
+}
+
+// PlaceholderProofOfProximity returns a proof of proximity with the slices
+// allocated for the given size of polynomial and options. It is used for
+// compiling the circuit.
+func PlaceholderProofOfProximity(size uint64, opts ...Option) ProofOfProximity {
+	cfg := newConfig(opts...)
+	nbSteps := bits.TrailingZeros64(size)
+	depth := nbSteps + cfg.logRho
+	var res ProofOfProximity
+	res.Rounds = make([]Round, cfg.nbRounds)
+	for i := range res.Rounds {
+		res.Rounds[i].Interactions = make([][2]merkle.MerkleProof, nbSteps)
+		for j := range res.Rounds[i].Interactions {
+			res.Rounds[i].Interactions[j][0].Path = make([]frontend.Variable, depth-j+1)
+			res.Rounds[i].Interactions[j][1].Path = make([]frontend.Variable, depth-j+1)
+		}
+	}
+	return res
+}
+
+func (s *Prover) buildProofOfProximitySingleRound(salt *big.Int, p []*big.Int) (Round, error) {
+
+	var res Round
+	res.Interactions = make([][2]merkle.MerkleProof, s.nbSteps)
+
+	xis := make([]string, s.nbSteps+1)
+	for i := 0; i < s.nbSteps; i++ {
+		xis[i] = fmt.Sprintf("x%d", i)
+	}
+	xis[s.nbSteps] = "s0"
+	fs := fiatshamir.NewTranscript(s.h, xis...)
+
+	// the salt is binded to the first challenge, to ensure the challenges
+	// are different at each round.
+	if err := fs.Bind(xis[0], s.marshal(salt)); err != nil {
+		return Round{}, err
+	}
+
+	// step 1 : commit to the sorted evaluations and fold them using the
+	// challenges.
+	trees := make([]*merkle.Tree, s.nbSteps)
+	var gInv big.Int
+	gInv.Set(&s.genInv)
+	cur := p
+	for i := 0; i < s.nbSteps; i++ {
+		sorted := sortFibers(cur)
+		tree, err := merkle.NewTree(s.h, s.nbSteps+s.logRho-i)
+		if err != nil {
+			return Round{}, err
+		}
+		for k := range sorted {
+			if _, err := tree.Insert(s.marshal(sorted[k])); err != nil {
+				return Round{}, err
+			}
+		}
+		trees[i] = tree
+
+		if err := fs.Bind(xis[i], tree.Root()); err != nil {
+			return Round{}, err
+		}
+		bxi, err := fs.ComputeChallenge(xis[i])
+		if err != nil {
+			return Round{}, err
+		}
+		xi := new(big.Int).SetBytes(bxi)
+		xi.Mod(xi, s.modulus)
+
+		cur = s.fold(sorted, &gInv, xi)
+		gInv.Mul(&gInv, &gInv).Mod(&gInv, s.modulus)
+	}
+
+	// the fully folded polynomial is constant on the domain of size \rho.
+	res.Evaluation = cur[0]
+
+	// step 2: provide the Merkle proofs of the queries
+	if err := fs.Bind(xis[s.nbSteps], s.marshal(cur[0])); err != nil {
+		return Round{}, err
+	}
+	binSeed, err := fs.ComputeChallenge(xis[s.nbSteps])
+	if err != nil {
+		return Round{}, err
+	}
+	var bPos big.Int
+	bPos.SetBytes(binSeed)
+	cardinality := s.size << s.logRho
+	bPos.Mod(&bPos, new(big.Int).SetUint64(cardinality))
+	si := deriveQueriesPositions(bPos.Uint64(), cardinality, s.nbSteps)
+
+	for i := 0; i < s.nbSteps; i++ {
+		even := si[i] - si[i]%2
+		for j := 0; j < 2; j++ {
+			mp, err := trees[i].Prove(even + uint64(j))
+			if err != nil {
+				return Round{}, err
+			}
+			res.Interactions[i][j] = mp
+		}
+	}
+
+	return res, nil
+}
+
+// evaluate returns the evaluations of p on the domain of size \rho * size in
+// natural order.
+func (s *Prover) evaluate(p []*big.Int) []*big.Int {
+	n := s.size << s.logRho
+	res := make([]*big.Int, n)
+	for i := range res {
+		res[i] = new(big.Int)
+		if i < len(p) {
+			res[i].Mod(p[i], s.modulus)
+		}
+	}
+	// bit-reverse the coefficients and apply iterative Cooley-Tukey FFT.
+	logN := bits.TrailingZeros64(n)
+	for i := uint64(0); i < n; i++ {
+		j := bits.Reverse64(i) >> (64 - logN)
+		if i < j {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+	var w, wm, t big.Int
+	for m := uint64(2); m <= n; m <<= 1 {
+		// wm is a primitive m-th root of unity
+		wm.Exp(&s.gen, new(big.Int).SetUint64(n/m), s.modulus)
+		for k := uint64(0); k < n; k += m {
+			w.SetUint64(1)
+			for j := uint64(0); j < m/2; j++ {
+				t.Mul(&w, res[k+j+m/2]).Mod(&t, s.modulus)
+				res[k+j+m/2].Sub(res[k+j], &t).Mod(res[k+j+m/2], s.modulus)
+				res[k+j].Add(res[k+j], &t).Mod(res[k+j], s.modulus)
+				w.Mul(&w, &wm).Mod(&w, s.modulus)
+			}
+		}
+	}
+	return res
+}
+
+// fold folds the sorted evaluations using the challenge x, see gnark-crypto.
+// It returns the evaluations of the folded polynomial in natural order.
+func (s *Prover) fold(sorted []*big.Int, gInv, x *big.Int) []*big.Int {
+	twoInv := new(big.Int).ModInverse(big.NewInt(2), s.modulus)
+	res := make([]*big.Int, len(sorted)/2)
+	acc := big.NewInt(1)
+	var p1, p2 big.Int
+	for i := range res {
+		p1.Add(sorted[2*i], sorted[2*i+1])
+		p2.Sub(sorted[2*i], sorted[2*i+1])
+		p2.Mul(&p2, acc)
+		res[i] = new(big.Int).Mul(&p2, x)
+		res[i].Add(res[i], &p1).Mul(res[i], twoInv).Mod(res[i], s.modulus)
+		acc.Mul(acc, gInv).Mod(acc, s.modulus)
+	}
+	return res
+}
+
+// initGenerator finds a generator of the cyclic group of unity of size \rho *
+// size.
+func (s *Prover) initGenerator() error {
+	n := new(big.Int).SetUint64(s.size << s.logRho)
+	pMinusOne := new(big.Int).Sub(s.modulus, big.NewInt(1))
+	var q, r big.Int
+	q.DivMod(pMinusOne, n, &r)
+	if r.Sign() != 0 {
+		return fmt.Errorf("field does not have a subgroup of size %s", n.String())
+	}
+	half := new(big.Int).Rsh(n, 1)
+	var g, t big.Int
+	for x := int64(2); x < 1000; x++ {
+		g.Exp(big.NewInt(x), &q, s.modulus)
+		// g is of order exactly n iff g^{n/2} = -1
+		if t.Exp(&g, half, s.modulus).Cmp(pMinusOne) == 0 {
+			s.gen.Set(&g)
+			s.genInv.ModInverse(&g, s.modulus)
+			return nil
+		}
+	}
+	return errors.New("could not find generator of the evaluation domain")
+}
+
+// marshal returns the big-endian encoding of v on the byte size of the field.
+func (s *Prover) marshal(v *big.Int) []byte {
+	res := make([]byte, (s.modulus.BitLen()+7)/8)
+	return v.FillBytes(res)
+}
+
+// sortFibers orders the evaluations such that contiguous entries are in the
+// same fiber of x -> x²:
+// {q(g⁰), q(g^{n/2}), q(g¹), q(g^{1+n/2}),...,q(g^{n/2-1}), q(gⁿ⁻¹)}
+func sortFibers(evaluations []*big.Int) []*big.Int {
+	n := len(evaluations) / 2
+	q := make([]*big.Int, len(evaluations))
+	for i := 0; i < n; i++ {
+		q[2*i] = evaluations[i]
+		q[2*i+1] = evaluations[i+n]
+	}
+	return q
+}
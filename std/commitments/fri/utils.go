@@ -62,18 +62,29 @@ var DeriveQueriesPositions = func(_ *big.Int, inputs []*big.Int, res []*big.Int)
 	s := inputs[1].Uint64()
 	nbSteps := inputs[2].Uint64()
 
-	res[0].SetUint64(pos)
-	for i := 1; i < int(nbSteps); i++ {
-		a := res[i-1].Uint64()
-		t := (a - (a % 2)) / 2
-		b := convertCanonicalSorted(int(t), int(s/2))
-		res[i].SetUint64(uint64(b))
-		s = s / 2
+	positions := deriveQueriesPositions(pos, s, int(nbSteps))
+	for i := range positions {
+		res[i].SetUint64(positions[i])
 	}
 
 	return nil
 }
 
+// deriveQueriesPositions is the native counterpart of [DeriveQueriesPositions].
+func deriveQueriesPositions(pos, size uint64, nbSteps int) []uint64 {
+
+	res := make([]uint64, nbSteps)
+	res[0] = pos
+	for i := 1; i < nbSteps; i++ {
+		a := res[i-1]
+		t := (a - (a % 2)) / 2
+		res[i] = uint64(convertCanonicalSorted(int(t), int(size/2)))
+		size = size / 2
+	}
+
+	return res
+}
+
 func init() {
 	solver.RegisterHint(DeriveQueriesPositions)
 }
@@ -390,16 +390,40 @@ func ValueOfVerifyingKeyFixed[G1El algebra.G1ElementT, G2El algebra.G2ElementT](
 	return ret, nil
 }
 
+type verifierCfg struct {
+	hashOpts []recursion.HashOption
+}
+
+// VerifierOption allows to modify the behaviour of the KZG verifier.
+type VerifierOption func(cfg *verifierCfg) error
+
+// WithHashFunction sets the hash function used for deriving the folding
+// challenges. The native prover must use the same hash function, see
+// [recursion.NewShort]. If not set, then MiMC is used.
+func WithHashFunction(hf recursion.HashFunction) VerifierOption {
+	return func(cfg *verifierCfg) error {
+		cfg.hashOpts = append(cfg.hashOpts, recursion.WithHashFunction(hf))
+		return nil
+	}
+}
+
 // Verifier allows verifying KZG opening proofs.
 type Verifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT] struct {
 	api       frontend.API
 	scalarApi *emulated.Field[FR]
 	curve     algebra.Curve[FR, G1El]
 	pairing   algebra.Pairing[G1El, G2El, GtEl]
+	hashOpts  []recursion.HashOption
 }
 
 // NewVerifier initializes a new Verifier instance.
-func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT](api frontend.API) (*Verifier[FR, G1El, G2El, GtEl], error) {
+func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.G2ElementT](api frontend.API, opts ...VerifierOption) (*Verifier[FR, G1El, G2El, GtEl], error) {
+	cfg := new(verifierCfg)
+	for i := range opts {
+		if err := opts[i](cfg); err != nil {
+			return nil, fmt.Errorf("option %d: %w", i, err)
+		}
+	}
 	curve, err := algebra.GetCurve[FR, G1El](api)
 	if err != nil {
 		return nil, err
@@ -417,6 +441,7 @@ func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.
 		scalarApi: scalarApi,
 		curve:     curve,
 		pairing:   pairing,
+		hashOpts:  cfg.hashOpts,
 	}, nil
 }
 
@@ -483,7 +508,7 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) FoldProofsMultiPoint(digests []Commitme
 	// sample random numbers λᵢ for sampling
 	randomNumbers := make([]*emulated.Element[FR], len(digests))
 	randomNumbers[0] = v.scalarApi.One()
-	whSnark, err := recursion.NewHash(v.api, fr.Modulus(), true)
+	whSnark, err := recursion.NewHash(v.api, fr.Modulus(), true, v.hashOpts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -647,7 +672,7 @@ func (v *Verifier[FR, G1El, G2El, GTEl]) FoldProof(digests []Commitment[G1El], b
 // dataTranscript are supposed to be bits.
 func (v *Verifier[FR, G1El, G2El, GTEl]) deriveGamma(point emulated.Element[FR], digests []Commitment[G1El], claimedValues []emulated.Element[FR], dataTranscript ...emulated.Element[FR]) (*emulated.Element[FR], error) {
 	var fr FR
-	fs, err := recursion.NewTranscript(v.api, fr.Modulus(), []string{"gamma"})
+	fs, err := recursion.NewTranscript(v.api, fr.Modulus(), []string{"gamma"}, v.hashOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("new transcript: %w", err)
 	}
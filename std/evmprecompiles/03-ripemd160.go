@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/ripemd160"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// RIPEMD160 implements [RIPEMD160] precompile contract at address 0x03.
+//
+// The length of the input is fixed at compile time. As in the EVM, the 20
+// bytes digest is left-padded with zeros to 32 bytes.
+//
+// [RIPEMD160]: https://ethereum.github.io/execution-specs/autoapi/ethereum/paris/vm/precompiled_contracts/ripemd160/index.html
+func RIPEMD160(api frontend.API, data []uints.U8) [32]uints.U8 {
+	h, err := ripemd160.New(api)
+	if err != nil {
+		panic(fmt.Sprintf("new ripemd160: %v", err))
+	}
+	h.Write(data)
+	dgst := h.Sum()
+	var res [32]uints.U8
+	for i := 0; i < 32-len(dgst); i++ {
+		res[i] = uints.NewU8(0)
+	}
+	copy(res[32-len(dgst):], dgst)
+	return res
+}
@@ -0,0 +1,42 @@
+package evmprecompiles
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/ripemd160"
+)
+
+type ripemd160Circuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *ripemd160Circuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	res := RIPEMD160(api, c.In)
+	for i := range res {
+		uapi.ByteAssertEq(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestRIPEMD160Circuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := []byte("hello world")
+	h := ripemd160.New()
+	h.Write(in)
+	expected := make([]byte, 12, 32)
+	expected = h.Sum(expected)
+	circuit := ripemd160Circuit{In: make([]uints.U8, len(in))}
+	witness := ripemd160Circuit{In: uints.NewU8Array(in)}
+	copy(witness.Expected[:], uints.NewU8Array(expected))
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+// Blake2F implements [BLAKE2F] precompile contract at address 0x09.
+//
+// The inputs are the state vector h, the message block vector m and the offset
+// counters t as little-endian 64-bit words, and the final block indicator flag
+// f. The number of rounds is fixed at compile time, so that a circuit has to
+// be defined for every number of rounds to support. The method returns the
+// updated state vector.
+//
+// [BLAKE2F]: https://eips.ethereum.org/EIPS/eip-152
+func Blake2F(api frontend.API, rounds int, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, f frontend.Variable) [8]uints.U64 {
+	if rounds < 0 {
+		panic("negative number of rounds")
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints: %v", err))
+	}
+	api.AssertIsBoolean(f)
+	return blake2.Compress2b(uapi, rounds, h, m, t, f)
+}
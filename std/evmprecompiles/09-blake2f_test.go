@@ -0,0 +1,135 @@
+package evmprecompiles
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2b"
+)
+
+type blake2fCircuit struct {
+	rounds   int
+	H        [8]uints.U64
+	M        [16]uints.U64
+	T        [2]uints.U64
+	F        frontend.Variable
+	Expected [8]uints.U64
+}
+
+func (c *blake2fCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	res := Blake2F(api, c.rounds, c.H, c.M, c.T, c.F)
+	for i := range res {
+		uapi.AssertEq(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+// blake2f is the native reference of the BLAKE2b compression function F as
+// defined in EIP-152.
+func blake2f(rounds int, h [8]uint64, m [16]uint64, t [2]uint64, f bool) [8]uint64 {
+	sigma := [10][16]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+		{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+		{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+		{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+		{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+		{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+		{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+		{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+		{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+	}
+	iv := [8]uint64{
+		0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	var v [16]uint64
+	copy(v[:8], h[:])
+	copy(v[8:], iv[:])
+	v[12] ^= t[0]
+	v[13] ^= t[1]
+	if f {
+		v[14] = ^v[14]
+	}
+	g := func(a, b, c, d int, x, y uint64) {
+		v[a] = v[a] + v[b] + x
+		v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+		v[a] = v[a] + v[b] + y
+		v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+	}
+	for i := 0; i < rounds; i++ {
+		s := sigma[i%10]
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+	return h
+}
+
+func testRoutineBlake2F(rounds int, h [8]uint64, m [16]uint64, t [2]uint64, f bool, expected [8]uint64) (circ, wit frontend.Circuit) {
+	circuit := blake2fCircuit{rounds: rounds}
+	witness := blake2fCircuit{F: 0}
+	if f {
+		witness.F = 1
+	}
+	copy(witness.H[:], uints.NewU64Array(h[:]))
+	copy(witness.M[:], uints.NewU64Array(m[:]))
+	copy(witness.T[:], uints.NewU64Array(t[:]))
+	copy(witness.Expected[:], uints.NewU64Array(expected[:]))
+	return &circuit, &witness
+}
+
+func TestBlake2FCircuit(t *testing.T) {
+	assert := test.NewAssert(t)
+	// EIP-152 test vector 4: BLAKE2b-512 of "abc" is a single compression with
+	// 12 rounds.
+	h := [8]uint64{
+		0x6a09e667f3bcc908 ^ 0x01010040, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	var m [16]uint64
+	m[0] = 0x636261
+	dgst := blake2b.Sum512([]byte("abc"))
+	var expected [8]uint64
+	for i := range expected {
+		expected[i] = binary.LittleEndian.Uint64(dgst[8*i:])
+	}
+	assert.Equal(expected, blake2f(12, h, m, [2]uint64{3, 0}, true))
+	circuit, witness := testRoutineBlake2F(12, h, m, [2]uint64{3, 0}, true, expected)
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	for _, rounds := range []int{0, 1, 20} {
+		for _, f := range []bool{false, true} {
+			assert.Run(func(assert *test.Assert) {
+				tt := [2]uint64{5, 1}
+				expected := blake2f(rounds, h, m, tt, f)
+				circuit, witness := testRoutineBlake2F(rounds, h, m, tt, f, expected)
+				err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, fmt.Sprintf("rounds=%d/f=%t", rounds, f))
+		}
+	}
+}
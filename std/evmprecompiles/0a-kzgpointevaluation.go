@@ -0,0 +1,166 @@
+package evmprecompiles
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// kzgSetupG2 is the compressed encoding of [τ]G₂ from the Ethereum KZG
+// ceremony, i.e. the second element of KZG_SETUP_G2 in EIP-4844.
+const kzgSetupG2 = "b5bfd7dd8cdeb128843bc287230af38926187075cbfbefa81009a2ce615ac53d2914e5870cb452d2afaaab24f3499f72185cbfee53492714734429b7b38608e23926c911cceceac9a36851477ba4c60b087041de621000edc98edada20c1def2"
+
+// versionedHashVersionKzg is the version byte of the versioned hash of a KZG
+// commitment.
+const versionedHashVersionKzg = 0x01
+
+// KzgPointEvaluation implements [POINT_EVALUATION] precompile contract at
+// address 0x0a.
+//
+// The method asserts that the versioned hash matches the commitment and that
+// the proof is a valid KZG opening proof of the commitment at the point z with
+// the claimed value y, using the trusted setup of the Ethereum KZG ceremony.
+// The commitment and the proof are given in the compressed ZCash encoding. The
+// precompile output (FIELD_ELEMENTS_PER_BLOB and BLS_MODULUS) is constant and
+// is not returned.
+//
+// The circuit is not satisfiable if any of the checks of the precompile fail:
+//  1. the versioned hash does not match the commitment;
+//  2. z or y is not canonical, i.e. not less than the scalar field modulus;
+//  3. the commitment or the proof is not a valid encoding of a G1 point;
+//  4. the opening proof is invalid.
+//
+// [POINT_EVALUATION]: https://eips.ethereum.org/EIPS/eip-4844#point-evaluation-precompile
+func KzgPointEvaluation(api frontend.API, versionedHash [32]uints.U8,
+	z, y *emulated.Element[sw_bls12381.ScalarField],
+	commitment, proof [48]uints.U8) {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	frField, err := emulated.NewField[sw_bls12381.ScalarField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	bf, err := uints.New[uints.U32](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints: %v", err))
+	}
+	h, err := sha2.New(api)
+	if err != nil {
+		panic(fmt.Sprintf("new sha2: %v", err))
+	}
+
+	// 1- versioned_hash == kzg_to_versioned_hash(commitment)
+	h.Write(commitment[:])
+	digest := h.Sum()
+	bf.ByteAssertEq(versionedHash[0], uints.NewU8(versionedHashVersionKzg))
+	for i := 1; i < len(versionedHash); i++ {
+		bf.ByteAssertEq(versionedHash[i], digest[i])
+	}
+
+	// 2- z and y are canonical
+	frField.AssertIsInRange(z)
+	frField.AssertIsInRange(y)
+
+	// 3- decompress the commitment and the proof. The points at infinity are
+	// represented as (0,0).
+	C, _ := decompressBLS12381G1(api, fpField, curve, pairing, commitment)
+	pi, isPiInf := decompressBLS12381G1(api, fpField, curve, pairing, proof)
+
+	// 4- check e([y]G₁ - [z]π - C, G₂) · e(π, [τ]G₂) == 1.
+	//
+	// The pairing does not handle the points at infinity. But the left
+	// operand is [-τ]π for a valid proof, so either both operands are at
+	// infinity and the check holds trivially, or both are not at infinity and
+	// we perform the pairing check. We substitute the generator for the points
+	// at infinity to keep the circuit satisfiable.
+	g := curve.Generator()
+	total := curve.JointScalarMulBase(pi, frField.Neg(z), y, algopts.WithCompleteArithmetic())
+	total = curve.AddUnified(total, curve.Neg(C))
+	isTotalInf := api.And(fpField.IsZero(&total.X), fpField.IsZero(&total.Y))
+	api.AssertIsEqual(isTotalInf, isPiInf)
+
+	_, _, _, g2 := bls12381.Generators()
+	tauBytes, err := hex.DecodeString(kzgSetupG2)
+	if err != nil {
+		panic(fmt.Sprintf("decode trusted setup: %v", err))
+	}
+	var tauG2 bls12381.G2Affine
+	if _, err := tauG2.SetBytes(tauBytes); err != nil {
+		panic(fmt.Sprintf("trusted setup: %v", err))
+	}
+	Q0 := sw_bls12381.NewG2AffineFixed(g2)
+	Q1 := sw_bls12381.NewG2AffineFixed(tauG2)
+	res, err := pairing.Pair(
+		[]*sw_bls12381.G1Affine{curve.Select(isTotalInf, g, total), curve.Select(isPiInf, g, pi)},
+		[]*sw_bls12381.G2Affine{&Q0, &Q1},
+	)
+	if err != nil {
+		panic(fmt.Sprintf("pair: %v", err))
+	}
+	one := pairing.One()
+	pairing.AssertIsEqual(pairing.Select(isPiInf, one, res), one)
+}
+
+// decompressBLS12381G1 returns the point encoded in the compressed ZCash
+// encoding and a boolean indicating if the point is at infinity. The point at
+// infinity is returned as (0,0). It asserts that the encoding is canonical and
+// that the point is in G1.
+func decompressBLS12381G1(api frontend.API, fpField *emulated.Field[sw_bls12381.BaseField],
+	curve *sw_emulated.Curve[sw_bls12381.BaseField, sw_bls12381.ScalarField],
+	pairing *sw_bls12381.Pairing, enc [48]uints.U8) (*sw_bls12381.G1Affine, frontend.Variable) {
+	// the three most significant bits of the encoding are the flags and the
+	// rest is the big-endian encoding of the x-coordinate.
+	xBits := make([]frontend.Variable, 0, 8*len(enc)-3)
+	for i := len(enc) - 1; i > 0; i-- {
+		xBits = append(xBits, api.ToBinary(enc[i].Val, 8)...)
+	}
+	msb := api.ToBinary(enc[0].Val, 8)
+	xBits = append(xBits, msb[:5]...)
+	isCompressed, isInf, sign := msb[7], msb[6], msb[5]
+	api.AssertIsEqual(isCompressed, 1)
+
+	X := fpField.FromBits(xBits...)
+	fpField.AssertIsInRange(X)
+	// the point at infinity has all the other bits set to zero.
+	fpField.AssertIsEqual(fpField.Select(isInf, fpField.Zero(), X), X)
+	api.AssertIsEqual(api.Mul(isInf, sign), 0)
+
+	// the y-coordinate is computed in the hint and constrained by checking
+	// that the point is in G1 and that its sign matches the flag. y is
+	// lexicographically largest iff p-y <= (p-1)/2.
+	ySign := fpField.Select(sign, fpField.One(), fpField.Zero())
+	Ys, err := fpField.NewHint(decompressBLS12381G1Hint, 1, X, ySign)
+	if err != nil {
+		panic(fmt.Sprintf("new hint: %v", err))
+	}
+	P := &sw_bls12381.G1Affine{X: *X, Y: *Ys[0]}
+	pairing.AssertIsOnG1(curve.Select(isInf, curve.Generator(), P))
+
+	var fp sw_bls12381.BaseField
+	halfP := new(big.Int).Rsh(fp.Modulus(), 1)
+	y := fpField.Reduce(fpField.Select(sign, fpField.Neg(Ys[0]), Ys[0]))
+	y = fpField.Select(isInf, fpField.Zero(), y)
+	fpField.AssertIsLessOrEqual(y, fpField.NewElement(halfP))
+
+	zero := &sw_bls12381.G1Affine{X: *fpField.Zero(), Y: *fpField.Zero()}
+	return curve.Select(isInf, zero, P), isInf
+}
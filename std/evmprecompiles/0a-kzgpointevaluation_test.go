@@ -0,0 +1,91 @@
+package evmprecompiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type kzgPointEvaluationCircuit struct {
+	VersionedHash [32]uints.U8
+	Z             emulated.Element[sw_bls12381.ScalarField]
+	Y             emulated.Element[sw_bls12381.ScalarField]
+	Commitment    [48]uints.U8
+	Proof         [48]uints.U8
+}
+
+func (c *kzgPointEvaluationCircuit) Define(api frontend.API) error {
+	KzgPointEvaluation(api, c.VersionedHash, &c.Z, &c.Y, c.Commitment, c.Proof)
+	return nil
+}
+
+// kzgPointEvaluationWitness builds the witness from the 192 bytes precompile
+// input.
+func kzgPointEvaluationWitness(t *testing.T, input []byte) *kzgPointEvaluationCircuit {
+	if len(input) != 192 {
+		t.Fatalf("invalid input length %d", len(input))
+	}
+	var z, y fr.Element
+	z.SetBytes(input[32:64])
+	y.SetBytes(input[64:96])
+	var w kzgPointEvaluationCircuit
+	copy(w.VersionedHash[:], uints.NewU8Array(input[0:32]))
+	w.Z = emulated.ValueOf[sw_bls12381.ScalarField](z)
+	w.Y = emulated.ValueOf[sw_bls12381.ScalarField](y)
+	copy(w.Commitment[:], uints.NewU8Array(input[96:144]))
+	copy(w.Proof[:], uints.NewU8Array(input[144:192]))
+	return &w
+}
+
+func TestKzgPointEvaluation(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vector from go-ethereum precompiled contracts tests
+	input, err := hex.DecodeString("01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d3630624d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a18f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481bc22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a")
+	assert.NoError(err)
+	witness := kzgPointEvaluationWitness(t, input)
+	err = test.IsSolved(&kzgPointEvaluationCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong claimed value
+	input[95] ^= 1
+	witness = kzgPointEvaluationWitness(t, input)
+	err = test.IsSolved(&kzgPointEvaluationCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	input[95] ^= 1
+
+	// wrong versioned hash
+	input[0] = 0x02
+	witness = kzgPointEvaluationWitness(t, input)
+	err = test.IsSolved(&kzgPointEvaluationCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestKzgPointEvaluationInfinity(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the zero polynomial evaluates to zero at any point with the proof at
+	// infinity.
+	input := make([]byte, 192)
+	input[96] = 0xc0
+	input[144] = 0xc0
+	h := sha256.Sum256(input[96:144])
+	copy(input[0:32], h[:])
+	input[0] = 0x01
+	input[63] = 0x2a
+	witness := kzgPointEvaluationWitness(t, input)
+	err := test.IsSolved(&kzgPointEvaluationCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// non-zero claimed value
+	input[95] = 1
+	witness = kzgPointEvaluationWitness(t, input)
+	err = test.IsSolved(&kzgPointEvaluationCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
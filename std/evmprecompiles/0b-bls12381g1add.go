@@ -0,0 +1,45 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECAddG1BLS implements [BLS12_G1ADD] precompile contract at address 0x0b.
+//
+// The point at infinity is represented as (0,0). The circuit is not
+// satisfiable if any of the coordinates is not canonical or if any of the
+// points is not on the curve. As per EIP-2537, the points are not checked to
+// be in G1.
+//
+// [BLS12_G1ADD]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g1-addition
+func ECAddG1BLS(api frontend.API, P, Q *sw_bls12381.G1Affine) *sw_bls12381.G1Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	// 1- Check that P and Q are canonical and on the curve
+	assertIsCanonicalBLS12381G1(fpField, P)
+	assertIsCanonicalBLS12381G1(fpField, Q)
+	curve.AssertIsOnCurve(P)
+	curve.AssertIsOnCurve(Q)
+
+	// 2- We use AddUnified because P can be equal to Q, -Q and either or both
+	// can be (0,0)
+	return curve.AddUnified(P, Q)
+}
+
+// assertIsCanonicalBLS12381G1 asserts that the coordinates of p are less than
+// the modulus of the base field.
+func assertIsCanonicalBLS12381G1(fpField *emulated.Field[sw_bls12381.BaseField], p *sw_bls12381.G1Affine) {
+	fpField.AssertIsInRange(&p.X)
+	fpField.AssertIsInRange(&p.Y)
+}
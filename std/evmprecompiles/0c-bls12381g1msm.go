@@ -0,0 +1,65 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMSMG1BLS implements [BLS12_G1MSM] precompile contract at address 0x0c.
+//
+// It computes ∑ᵢ [sᵢ]Pᵢ. The point at infinity is represented as (0,0). The
+// circuit is not satisfiable if any of the coordinates is not canonical or if
+// any of the points is not in G1. The scalars are 32 bytes integers in the
+// precompile but as the points are in G1, they can be given reduced modulo
+// the scalar field order.
+//
+// [BLS12_G1MSM]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g1-multiexponentiation
+func ECMSMG1BLS(api frontend.API, P []*sw_bls12381.G1Affine, s []*emulated.Element[sw_bls12381.ScalarField]) *sw_bls12381.G1Affine {
+	if len(P) != len(s) {
+		panic("P and s length mismatch")
+	}
+	if len(P) == 0 {
+		panic("empty multi-scalar multiplication")
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	// 1- Check that Pᵢ are canonical and in G1
+	for i := range P {
+		assertIsOnBLS12381G1(api, fpField, curve, pairing, P[i])
+	}
+
+	// 2- Compute the MSM with complete arithmetic as the points can be (0,0)
+	// and the scalars can be zero
+	res, err := curve.MultiScalarMul(P, s, algopts.WithCompleteArithmetic())
+	if err != nil {
+		panic(fmt.Sprintf("multi-scalar multiplication: %v", err))
+	}
+	return res
+}
+
+// assertIsOnBLS12381G1 asserts that p is canonical and either (0,0) or in G1.
+// It returns a boolean indicating if p is (0,0).
+func assertIsOnBLS12381G1(api frontend.API, fpField *emulated.Field[sw_bls12381.BaseField],
+	curve *sw_emulated.Curve[sw_bls12381.BaseField, sw_bls12381.ScalarField],
+	pairing *sw_bls12381.Pairing, p *sw_bls12381.G1Affine) frontend.Variable {
+	assertIsCanonicalBLS12381G1(fpField, p)
+	// the subgroup check doesn't handle (0,0), we substitute the generator
+	isInf := api.And(fpField.IsZero(&p.X), fpField.IsZero(&p.Y))
+	pairing.AssertIsOnG1(curve.Select(isInf, curve.Generator(), p))
+	return isInf
+}
@@ -0,0 +1,47 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECAddG2BLS implements [BLS12_G2ADD] precompile contract at address 0x0d.
+//
+// The point at infinity is represented as (0,0). The circuit is not
+// satisfiable if any of the coordinates is not canonical or if any of the
+// points is not on the twist. As per EIP-2537, the points are not checked to
+// be in G2.
+//
+// [BLS12_G2ADD]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g2-addition
+func ECAddG2BLS(api frontend.API, P, Q *sw_bls12381.G2Affine) *sw_bls12381.G2Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	g2 := sw_bls12381.NewG2(api)
+	// 1- Check that P and Q are canonical and on the twist
+	assertIsCanonicalBLS12381G2(fpField, P)
+	assertIsCanonicalBLS12381G2(fpField, Q)
+	pairing.AssertIsOnTwist(P)
+	pairing.AssertIsOnTwist(Q)
+
+	// 2- We use AddUnified because P can be equal to Q, -Q and either or both
+	// can be (0,0)
+	return g2.AddUnified(P, Q)
+}
+
+// assertIsCanonicalBLS12381G2 asserts that the coordinates of p are less than
+// the modulus of the base field.
+func assertIsCanonicalBLS12381G2(fpField *emulated.Field[sw_bls12381.BaseField], p *sw_bls12381.G2Affine) {
+	fpField.AssertIsInRange(&p.P.X.A0)
+	fpField.AssertIsInRange(&p.P.X.A1)
+	fpField.AssertIsInRange(&p.P.Y.A0)
+	fpField.AssertIsInRange(&p.P.Y.A1)
+}
@@ -0,0 +1,63 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMSMG2BLS implements [BLS12_G2MSM] precompile contract at address 0x0e.
+//
+// It computes ∑ᵢ [sᵢ]Qᵢ. The point at infinity is represented as (0,0). The
+// circuit is not satisfiable if any of the coordinates is not canonical or if
+// any of the points is not in G2. The scalars are 32 bytes integers in the
+// precompile but as the points are in G2, they can be given reduced modulo
+// the scalar field order. The doublings are shared between all the points,
+// see [sw_bls12381.G2.MultiScalarMul].
+//
+// [BLS12_G2MSM]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g2-multiexponentiation
+func ECMSMG2BLS(api frontend.API, Q []*sw_bls12381.G2Affine, s []*emulated.Element[sw_bls12381.ScalarField]) *sw_bls12381.G2Affine {
+	if len(Q) != len(s) {
+		panic("Q and s length mismatch")
+	}
+	if len(Q) == 0 {
+		panic("empty multi-scalar multiplication")
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	g2 := sw_bls12381.NewG2(api)
+	// 1- Check that Qᵢ are canonical and in G2
+	for i := range Q {
+		assertIsOnBLS12381G2(api, fpField, g2, pairing, Q[i])
+	}
+
+	// 2- Compute the MSM with complete arithmetic as the points can be (0,0)
+	// and the scalars can be zero
+	res, err := g2.MultiScalarMul(Q, s)
+	if err != nil {
+		panic(fmt.Sprintf("multi-scalar multiplication: %v", err))
+	}
+	return res
+}
+
+// assertIsOnBLS12381G2 asserts that q is canonical and either (0,0) or in G2.
+// It returns a boolean indicating if q is (0,0).
+func assertIsOnBLS12381G2(api frontend.API, fpField *emulated.Field[sw_bls12381.BaseField],
+	g2 *sw_bls12381.G2, pairing *sw_bls12381.Pairing, q *sw_bls12381.G2Affine) frontend.Variable {
+	assertIsCanonicalBLS12381G2(fpField, q)
+	// the subgroup check doesn't handle (0,0), we substitute the generator
+	isInf := api.And(g2.Ext2.IsZero(&q.P.X), g2.Ext2.IsZero(&q.P.Y))
+	_, _, _, gen := bls12381.Generators()
+	genAff := sw_bls12381.NewG2Affine(gen)
+	pairing.AssertIsOnG2(g2.Select(isInf, &genAff, q))
+	return isInf
+}
@@ -0,0 +1,69 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECPairBLS implements [BLS12_PAIRING_CHECK] precompile contract at address
+// 0x0f.
+//
+// It returns 1 if ∏ᵢ e(Pᵢ, Qᵢ) == 1 and 0 otherwise. The point at infinity is
+// represented as (0,0) and a pair with a point at infinity doesn't contribute
+// to the product. The circuit is not satisfiable if any of the coordinates is
+// not canonical or if any of the points is not in the corresponding subgroup.
+//
+// [BLS12_PAIRING_CHECK]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-pairing-check
+func ECPairBLS(api frontend.API, P []*sw_bls12381.G1Affine, Q []*sw_bls12381.G2Affine) frontend.Variable {
+	if len(P) != len(Q) {
+		panic("P and Q length mismatch")
+	}
+	if len(P) == 0 {
+		panic("empty pairing check")
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	g2 := sw_bls12381.NewG2(api)
+	_, _, _, gen := bls12381.Generators()
+	g2Gen := sw_bls12381.NewG2Affine(gen)
+
+	// The Miller loop doesn't handle the points at infinity. We substitute the
+	// generators and skip the pair in the product.
+	ml := pairing.One()
+	for i := range P {
+		// 1- Check that Pᵢ is in G1 and Qᵢ is in G2
+		isInfP := assertIsOnBLS12381G1(api, fpField, curve, pairing, P[i])
+		isInfQ := assertIsOnBLS12381G2(api, fpField, g2, pairing, Q[i])
+		skip := api.Or(isInfP, isInfQ)
+
+		// 2- Accumulate the Miller loops
+		mli, err := pairing.MillerLoop(
+			[]*sw_bls12381.G1Affine{curve.Select(isInfP, curve.Generator(), P[i])},
+			[]*sw_bls12381.G2Affine{g2.Select(isInfQ, &g2Gen, Q[i])},
+		)
+		if err != nil {
+			panic(fmt.Sprintf("miller loop: %v", err))
+		}
+		ml = pairing.Mul(ml, pairing.Select(skip, pairing.One(), mli))
+	}
+
+	// 3- Check that ∏ᵢ e(Pᵢ, Qᵢ) == 1. The product can be 1 before the final
+	// exponentiation, so we use the safe version.
+	res := pairing.FinalExponentiation(ml)
+	return pairing.IsZero(pairing.Sub(res, pairing.One()))
+}
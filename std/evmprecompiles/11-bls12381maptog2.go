@@ -0,0 +1,31 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMapToG2BLS implements [BLS12_MAP_FP2_TO_G2] precompile contract at
+// address 0x11.
+//
+// The circuit is not satisfiable if u is not canonical.
+//
+// [BLS12_MAP_FP2_TO_G2]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-mapping-fp2-element-to-g2-point
+func ECMapToG2BLS(api frontend.API, u *fields_bls12381.E2) *sw_bls12381.G2Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	g2 := sw_bls12381.NewG2(api)
+	fpField.AssertIsInRange(&u.A0)
+	fpField.AssertIsInRange(&u.A1)
+	res, err := g2.MapToG2(u)
+	if err != nil {
+		panic(fmt.Sprintf("map to G2: %v", err))
+	}
+	return res
+}
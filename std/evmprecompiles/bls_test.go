@@ -0,0 +1,327 @@
+package evmprecompiles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func randomBLS12381G1() bls12381.G1Affine {
+	_, _, g1, _ := bls12381.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var res bls12381.G1Affine
+	res.ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	return res
+}
+
+func randomBLS12381G2() bls12381.G2Affine {
+	_, _, _, g2 := bls12381.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var res bls12381.G2Affine
+	res.ScalarMultiplication(&g2, s.BigInt(new(big.Int)))
+	return res
+}
+
+// randomBLS12381E1 returns a point on the curve which is not in G1.
+func randomBLS12381E1() bls12381.G1Affine {
+	var res bls12381.G1Affine
+	for {
+		res.X.SetRandom()
+		res.Y.Square(&res.X).Mul(&res.Y, &res.X).Add(&res.Y, new(fp.Element).SetUint64(4))
+		if res.Y.Sqrt(&res.Y) != nil && !res.IsInSubGroup() {
+			return res
+		}
+	}
+}
+
+type blsG1AddCircuit struct {
+	P, Q     sw_bls12381.G1Affine
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *blsG1AddCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECAddG1BLS(api, &c.P, &c.Q)
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECAddG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, Q := randomBLS12381G1(), randomBLS12381E1()
+	var negP, zero bls12381.G1Affine
+	negP.Neg(&P)
+	for _, c := range []struct {
+		name string
+		P, Q bls12381.G1Affine
+	}{
+		{"random", P, Q},
+		{"double", P, P},
+		{"opposite", P, negP},
+		{"infinity", zero, Q},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G1Affine
+			expected.Add(&c.P, &c.Q)
+			witness := blsG1AddCircuit{
+				P:        sw_bls12381.NewG1Affine(c.P),
+				Q:        sw_bls12381.NewG1Affine(c.Q),
+				Expected: sw_bls12381.NewG1Affine(expected),
+			}
+			err := test.IsSolved(&blsG1AddCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+
+	// point not on the curve
+	invalid := P
+	invalid.Y.Double(&invalid.Y)
+	witness := blsG1AddCircuit{
+		P:        sw_bls12381.NewG1Affine(invalid),
+		Q:        sw_bls12381.NewG1Affine(negP),
+		Expected: sw_bls12381.NewG1Affine(bls12381.G1Affine{}),
+	}
+	err := test.IsSolved(&blsG1AddCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type blsG1MSMCircuit struct {
+	P        [2]sw_bls12381.G1Affine
+	S        [2]sw_bls12381.Scalar
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *blsG1MSMCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECMSMG1BLS(api, []*sw_bls12381.G1Affine{&c.P[0], &c.P[1]}, []*emulated.Element[sw_bls12381.ScalarField]{&c.S[0], &c.S[1]})
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMSMG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P := []bls12381.G1Affine{randomBLS12381G1(), randomBLS12381G1()}
+	var s0, s1 fr.Element
+	s0.SetRandom()
+	s1.SetRandom()
+	for _, c := range []struct {
+		name string
+		P    []bls12381.G1Affine
+		S    []fr.Element
+	}{
+		{"random", P, []fr.Element{s0, s1}},
+		{"zero scalar", P, []fr.Element{s0, {}}},
+		{"infinity", []bls12381.G1Affine{P[0], {}}, []fr.Element{s0, s1}},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G1Affine
+			if _, err := expected.MultiExp(c.P, c.S, ecc.MultiExpConfig{}); err != nil {
+				assert.FailNow("multiexp", err)
+			}
+			witness := blsG1MSMCircuit{
+				P:        [2]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(c.P[0]), sw_bls12381.NewG1Affine(c.P[1])},
+				S:        [2]sw_bls12381.Scalar{sw_bls12381.NewScalar(c.S[0]), sw_bls12381.NewScalar(c.S[1])},
+				Expected: sw_bls12381.NewG1Affine(expected),
+			}
+			err := test.IsSolved(&blsG1MSMCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+
+	// point not in G1
+	invalid := randomBLS12381E1()
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&invalid, s0.BigInt(new(big.Int)))
+	witness := blsG1MSMCircuit{
+		P:        [2]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(invalid), sw_bls12381.NewG1Affine(P[1])},
+		S:        [2]sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(fr.Element{})},
+		Expected: sw_bls12381.NewG1Affine(expected),
+	}
+	err := test.IsSolved(&blsG1MSMCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type blsG2AddCircuit struct {
+	P, Q     sw_bls12381.G2Affine
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *blsG2AddCircuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	res := ECAddG2BLS(api, &c.P, &c.Q)
+	g2.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECAddG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, Q := randomBLS12381G2(), randomBLS12381G2()
+	var negP, zero bls12381.G2Affine
+	negP.Neg(&P)
+	// -φ(P) = (ω·x, -y) with ω a cube root of unity is in G2 and has the
+	// opposite y coordinate of P.
+	var omega fp.Element
+	omega.SetString("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
+	negPhiP := negP
+	negPhiP.X.MulByElement(&negPhiP.X, &omega)
+	for _, c := range []struct {
+		name string
+		P, Q bls12381.G2Affine
+	}{
+		{"random", P, Q},
+		{"double", P, P},
+		{"opposite", P, negP},
+		{"opposite y", P, negPhiP},
+		{"infinity", zero, Q},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			var expected bls12381.G2Affine
+			expected.Add(&c.P, &c.Q)
+			witness := blsG2AddCircuit{
+				P:        sw_bls12381.NewG2Affine(c.P),
+				Q:        sw_bls12381.NewG2Affine(c.Q),
+				Expected: sw_bls12381.NewG2Affine(expected),
+			}
+			err := test.IsSolved(&blsG2AddCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
+
+type blsG2MSMCircuit struct {
+	Q        [2]sw_bls12381.G2Affine
+	S        [2]sw_bls12381.Scalar
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *blsG2MSMCircuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	res := ECMSMG2BLS(api, []*sw_bls12381.G2Affine{&c.Q[0], &c.Q[1]}, []*emulated.Element[sw_bls12381.ScalarField]{&c.S[0], &c.S[1]})
+	g2.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMSMG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	Q := []bls12381.G2Affine{randomBLS12381G2(), {}}
+	var s0, s1 fr.Element
+	s0.SetRandom()
+	s1.SetRandom()
+	var expected bls12381.G2Affine
+	expected.ScalarMultiplication(&Q[0], s0.BigInt(new(big.Int)))
+	witness := blsG2MSMCircuit{
+		Q:        [2]sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q[0]), sw_bls12381.NewG2Affine(Q[1])},
+		S:        [2]sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+		Expected: sw_bls12381.NewG2Affine(expected),
+	}
+	err := test.IsSolved(&blsG2MSMCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type blsPairCircuit struct {
+	P        [2]sw_bls12381.G1Affine
+	Q        [2]sw_bls12381.G2Affine
+	Expected frontend.Variable
+}
+
+func (c *blsPairCircuit) Define(api frontend.API) error {
+	res := ECPairBLS(api, []*sw_bls12381.G1Affine{&c.P[0], &c.P[1]}, []*sw_bls12381.G2Affine{&c.Q[0], &c.Q[1]})
+	api.AssertIsEqual(res, c.Expected)
+	return nil
+}
+
+func TestECPairBLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, Q := randomBLS12381G1(), randomBLS12381G2()
+	var negP bls12381.G1Affine
+	negP.Neg(&P)
+	for _, c := range []struct {
+		name     string
+		P        []bls12381.G1Affine
+		Q        []bls12381.G2Affine
+		expected int
+	}{
+		{"valid", []bls12381.G1Affine{P, negP}, []bls12381.G2Affine{Q, Q}, 1},
+		{"invalid", []bls12381.G1Affine{P, P}, []bls12381.G2Affine{Q, Q}, 0},
+		{"infinity", []bls12381.G1Affine{{}, P}, []bls12381.G2Affine{Q, {}}, 1},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			witness := blsPairCircuit{
+				P:        [2]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(c.P[0]), sw_bls12381.NewG1Affine(c.P[1])},
+				Q:        [2]sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(c.Q[0]), sw_bls12381.NewG2Affine(c.Q[1])},
+				Expected: c.expected,
+			}
+			err := test.IsSolved(&blsPairCircuit{}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, c.name)
+	}
+}
+
+type blsMapToG1Circuit struct {
+	U        emulated.Element[sw_bls12381.BaseField]
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *blsMapToG1Circuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECMapToG1BLS(api, &c.U)
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMapToG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	witness := blsMapToG1Circuit{
+		U:        emulated.ValueOf[sw_bls12381.BaseField](u),
+		Expected: sw_bls12381.NewG1Affine(bls12381.MapToG1(u)),
+	}
+	err := test.IsSolved(&blsMapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type blsMapToG2Circuit struct {
+	U        fields_bls12381.E2
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *blsMapToG2Circuit) Define(api frontend.API) error {
+	g2 := sw_bls12381.NewG2(api)
+	res := ECMapToG2BLS(api, &c.U)
+	g2.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMapToG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u bls12381.E2
+	u.SetRandom()
+	witness := blsMapToG2Circuit{
+		U:        fields_bls12381.FromE2(&u),
+		Expected: sw_bls12381.NewG2Affine(bls12381.MapToG2(u)),
+	}
+	err := test.IsSolved(&blsMapToG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
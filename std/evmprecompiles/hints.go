@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
@@ -17,7 +18,7 @@ func init() {
 
 // GetHints returns all the hints used in this package.
 func GetHints() []solver.Hint {
-	return []solver.Hint{recoverPublicKeyHint}
+	return []solver.Hint{recoverPublicKeyHint, decompressBLS12381G1Hint}
 }
 
 func recoverPublicKeyHintArgs(msg emulated.Element[emulated.Secp256k1Fr],
@@ -77,3 +78,27 @@ func recoverPublicKeyHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) err
 	outputs[2*emfp.NbLimbs()].SetInt64(int64(isZero))
 	return nil
 }
+
+func decompressBLS12381G1Hint(mod *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	// x and sign flag as inputs, y as output
+	return emulated.UnwrapHint(inputs, outputs, func(_ *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 {
+			return fmt.Errorf("expecting two inputs")
+		}
+		if len(outputs) != 1 {
+			return fmt.Errorf("expecting one output")
+		}
+		var x, y fp.Element
+		x.SetBigInt(inputs[0])
+		// y² = x³ + 4
+		y.Square(&x).Mul(&y, &x).Add(&y, new(fp.Element).SetUint64(4))
+		if y.Sqrt(&y) == nil {
+			return fmt.Errorf("x is not on the curve")
+		}
+		if y.LexicographicallyLargest() != (inputs[1].Sign() != 0) {
+			y.Neg(&y)
+		}
+		y.BigInt(outputs[0])
+		return nil
+	})
+}
@@ -0,0 +1,104 @@
+// Package blake2b implements BLAKE2b hash computation.
+//
+// This package extends the BLAKE2b compression function [blake2] into a full
+// BLAKE2b hash as defined in RFC 7693. Keyed hashing is not supported.
+//
+// Instances correspond to golang.org/x/crypto/blake2b.
+package blake2b
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+// BlockSize is the block size of BLAKE2b in bytes.
+const BlockSize = 128
+
+type digest struct {
+	uapi *uints.BinaryField[uints.U64]
+	in   []uints.U8
+	size int
+}
+
+// New returns a new BLAKE2b hash computing digests of size bytes. The size
+// must be between 1 and 64.
+func New(api frontend.API, size int) (hash.BinaryHasher, error) {
+	if size < 1 || size > 64 {
+		return nil, fmt.Errorf("invalid digest size %d", size)
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{uapi: uapi, size: size}, nil
+}
+
+// New256 returns a new BLAKE2b-256 hash.
+func New256(api frontend.API) (hash.BinaryHasher, error) {
+	return New(api, 32)
+}
+
+// New384 returns a new BLAKE2b-384 hash.
+func New384(api frontend.API) (hash.BinaryHasher, error) {
+	return New(api, 48)
+}
+
+// New512 returns a new BLAKE2b-512 hash.
+func New512(api frontend.API) (hash.BinaryHasher, error) {
+	return New(api, 64)
+}
+
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+func (d *digest) Sum() []uints.U8 {
+	// parameter block without key, fanout and depth 1
+	iv := blake2.IV2b
+	iv[0] ^= 0x01010000 ^ uint64(d.size)
+	var h [8]uints.U64
+	copy(h[:], uints.NewU64Array(iv[:]))
+
+	nbBlocks := (len(d.in) + BlockSize - 1) / BlockSize
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	for i := 0; i < nbBlocks; i++ {
+		var m [16]uints.U64
+		for j := range m {
+			var w [8]uints.U8
+			for k := range w {
+				if idx := i*BlockSize + 8*j + k; idx < len(d.in) {
+					w[k] = d.in[idx]
+				} else {
+					w[k] = uints.NewU8(0)
+				}
+			}
+			m[j] = d.uapi.PackLSB(w[:]...)
+		}
+		// the counter is the number of bytes processed including the
+		// current block.
+		t := uint64(min((i+1)*BlockSize, len(d.in)))
+		var f frontend.Variable = 0
+		if i == nbBlocks-1 {
+			f = 1
+		}
+		h = blake2.Compress2b(d.uapi, blake2.Rounds2b, h, m, [2]uints.U64{uints.NewU64(t), uints.NewU64(0)}, f)
+	}
+
+	var ret []uints.U8
+	for i := range h {
+		ret = append(ret, d.uapi.UnpackLSB(h[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+func (d *digest) Size() int { return d.size }
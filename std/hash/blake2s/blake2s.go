@@ -0,0 +1,94 @@
+// Package blake2s implements BLAKE2s hash computation.
+//
+// This package extends the BLAKE2s compression function [blake2] into a full
+// BLAKE2s hash as defined in RFC 7693. Keyed hashing is not supported.
+//
+// Instances correspond to golang.org/x/crypto/blake2s.
+package blake2s
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2"
+)
+
+// BlockSize is the block size of BLAKE2s in bytes.
+const BlockSize = 64
+
+type digest struct {
+	uapi *uints.BinaryField[uints.U32]
+	in   []uints.U8
+	size int
+}
+
+// New returns a new BLAKE2s hash computing digests of size bytes. The size
+// must be between 1 and 32.
+func New(api frontend.API, size int) (hash.BinaryHasher, error) {
+	if size < 1 || size > 32 {
+		return nil, fmt.Errorf("invalid digest size %d", size)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{uapi: uapi, size: size}, nil
+}
+
+// New256 returns a new BLAKE2s-256 hash.
+func New256(api frontend.API) (hash.BinaryHasher, error) {
+	return New(api, 32)
+}
+
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+func (d *digest) Sum() []uints.U8 {
+	// parameter block without key, fanout and depth 1
+	iv := blake2.IV2s
+	iv[0] ^= 0x01010000 ^ uint32(d.size)
+	var h [8]uints.U32
+	copy(h[:], uints.NewU32Array(iv[:]))
+
+	nbBlocks := (len(d.in) + BlockSize - 1) / BlockSize
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	for i := 0; i < nbBlocks; i++ {
+		var m [16]uints.U32
+		for j := range m {
+			var w [4]uints.U8
+			for k := range w {
+				if idx := i*BlockSize + 4*j + k; idx < len(d.in) {
+					w[k] = d.in[idx]
+				} else {
+					w[k] = uints.NewU8(0)
+				}
+			}
+			m[j] = d.uapi.PackLSB(w[:]...)
+		}
+		// the counter is the number of bytes processed including the
+		// current block.
+		t := uint32(min((i+1)*BlockSize, len(d.in)))
+		var f frontend.Variable = 0
+		if i == nbBlocks-1 {
+			f = 1
+		}
+		h = blake2.Compress2s(d.uapi, blake2.Rounds2s, h, m, [2]uints.U32{uints.NewU32(t), uints.NewU32(0)}, f)
+	}
+
+	var ret []uints.U8
+	for i := range h {
+		ret = append(ret, d.uapi.UnpackLSB(h[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+func (d *digest) Size() int { return d.size }
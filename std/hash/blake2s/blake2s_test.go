@@ -0,0 +1,59 @@
+package blake2s
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2s"
+)
+
+type blake2sCircuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *blake2sCircuit) Define(api frontend.API) error {
+	h, err := New(api, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("not %d bytes", len(c.Expected))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlake2s(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, size := range []int{32} {
+		for _, l := range []int{0, 3, 64, 100} {
+			assert.Run(func(assert *test.Assert) {
+				bts := make([]byte, l)
+				for i := range bts {
+					bts[i] = byte(i)
+				}
+				hf, err := blake2s.New256(nil)
+				assert.NoError(err)
+				hf.Write(bts)
+				dgst := hf.Sum(nil)
+				circuit := blake2sCircuit{In: make([]uints.U8, l), Expected: make([]uints.U8, size)}
+				witness := blake2sCircuit{In: uints.NewU8Array(bts), Expected: uints.NewU8Array(dgst)}
+				err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, fmt.Sprintf("size=%d/len=%d", size, l))
+		}
+	}
+}
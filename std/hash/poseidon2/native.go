@@ -0,0 +1,100 @@
+package poseidon2
+
+import (
+	"errors"
+	stdhash "hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+type nativeHasher struct {
+	params    *poseidon2.Parameters
+	blockSize int
+	h         *big.Int
+	data      []*big.Int
+}
+
+// NewNativeHasher returns the out-of-circuit counterpart of [Poseidon2] for
+// the scalar field of the given curve.
+//
+// Similarly to MiMC in gnark-crypto, the inputs written to the hasher must be
+// big-endian encodings of canonical field elements. Inputs shorter than a
+// field element are left-padded with zeros. The returned digest is the
+// big-endian encoding of the field element.
+//
+// The returned hash function can be used as a challenge hash function using
+// [github.com/consensys/gnark/backend.WithProverChallengeHashFunction].
+func NewNativeHasher(curve ecc.ID) (stdhash.Hash, error) {
+	params, err := poseidon2.GetDefaultParameters(curve)
+	if err != nil {
+		return nil, err
+	}
+	return NewNativeHasherFromParameters(params)
+}
+
+// NewNativeHasherFromParameters returns the out-of-circuit counterpart of
+// [Poseidon2] for the given parameters. The parameters must define a
+// permutation of width 2.
+func NewNativeHasherFromParameters(params *poseidon2.Parameters) (stdhash.Hash, error) {
+	if params.Width != 2 {
+		return nil, poseidon2.ErrCompressionWidth
+	}
+	return &nativeHasher{
+		params:    params,
+		blockSize: (params.Modulus.BitLen() + 7) / 8,
+		h:         new(big.Int),
+	}, nil
+}
+
+func (d *nativeHasher) Write(p []byte) (int, error) {
+	// we usually expect multiple of block size. But sometimes we hash short
+	// values (FS transcript). Instead of forcing to hash to field, we left-pad
+	// the input here.
+	if len(p) > 0 && len(p) < d.blockSize {
+		pp := make([]byte, d.blockSize)
+		copy(pp[len(pp)-len(p):], p)
+		p = pp
+	}
+	if len(p)%d.blockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	elems := make([]*big.Int, 0, len(p)/d.blockSize)
+	for start := 0; start < len(p); start += d.blockSize {
+		e := new(big.Int).SetBytes(p[start : start+d.blockSize])
+		if e.Cmp(d.params.Modulus) >= 0 {
+			return 0, errors.New("invalid input: not a canonical field element")
+		}
+		elems = append(elems, e)
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+func (d *nativeHasher) Sum(b []byte) []byte {
+	for _, e := range d.data {
+		res, err := d.params.Compress(d.h, e)
+		if err != nil {
+			panic(err) // can't error, width is checked in constructor
+		}
+		d.h = res
+	}
+	d.data = nil
+	res := make([]byte, d.blockSize)
+	d.h.FillBytes(res)
+	return append(b, res...)
+}
+
+func (d *nativeHasher) Reset() {
+	d.h = new(big.Int)
+	d.data = nil
+}
+
+func (d *nativeHasher) Size() int {
+	return d.blockSize
+}
+
+func (d *nativeHasher) BlockSize() int {
+	return d.blockSize
+}
@@ -0,0 +1,84 @@
+// Package poseidon2 implements the Poseidon2 hash function.
+//
+// The hash function applies the Merkle-Damgård construction over the
+// two-to-one compression function of the Poseidon2 permutation defined in
+// [poseidon2]. The initial chaining value is zero and every written field
+// element is absorbed with a single call to the compression function. Unlike
+// MiMC, the construction does not apply any padding, thus the caller has to
+// ensure that the number of elements hashed is fixed or is part of the input.
+//
+// The hash function is registered in [hash] under the name "POSEIDON2" and can
+// be retrieved using [hash.GetFieldHasher].
+//
+// The out-of-circuit counterpart of the hash function is given by
+// [NewNativeHasher], which implements the standard library hash.Hash interface
+// and can be used as a challenge hash function in the backends.
+package poseidon2
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+func init() {
+	hash.Register("POSEIDON2", func(api frontend.API) (hash.FieldHasher, error) {
+		h, err := NewPoseidon2(api)
+		if err != nil {
+			return nil, err
+		}
+		return &h, nil
+	})
+}
+
+// Poseidon2 is the in-circuit Poseidon2 hash function.
+type Poseidon2 struct {
+	api  frontend.API
+	perm *poseidon2.Permutation
+	h    frontend.Variable   // current chaining value
+	data []frontend.Variable // data to be absorbed on next call to Sum
+}
+
+// NewPoseidon2 returns a Poseidon2 hasher using the default parameters for the
+// native field. See [poseidon2.GetDefaultParameters] for the supported fields.
+func NewPoseidon2(api frontend.API) (Poseidon2, error) {
+	perm, err := poseidon2.NewPoseidon2(api)
+	if err != nil {
+		return Poseidon2{}, err
+	}
+	return Poseidon2{api: api, perm: perm, h: 0}, nil
+}
+
+// NewPoseidon2FromParameters returns a Poseidon2 hasher using the given
+// parameters. The parameters must define a permutation of width 2.
+func NewPoseidon2FromParameters(api frontend.API, params *poseidon2.Parameters) (Poseidon2, error) {
+	if params.Width != 2 {
+		return Poseidon2{}, poseidon2.ErrCompressionWidth
+	}
+	perm, err := poseidon2.NewPoseidon2FromParameters(api, params)
+	if err != nil {
+		return Poseidon2{}, err
+	}
+	return Poseidon2{api: api, perm: perm, h: 0}, nil
+}
+
+// Write adds more data to the running hash.
+func (h *Poseidon2) Write(data ...frontend.Variable) {
+	h.data = append(h.data, data...)
+}
+
+// Reset resets the Hash to its initial state.
+func (h *Poseidon2) Reset() {
+	h.data = nil
+	h.h = 0
+}
+
+// Sum returns the current digest. The written data is absorbed into the
+// chaining value, so that subsequent writes continue the running hash.
+func (h *Poseidon2) Sum() frontend.Variable {
+	for _, d := range h.data {
+		h.h = h.perm.Compress(h.h, d)
+	}
+	h.data = nil
+	return h.h
+}
@@ -0,0 +1,106 @@
+package poseidon2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+type poseidon2Circuit struct {
+	ExpectedResult frontend.Variable `gnark:"data,public"`
+	Data           [10]frontend.Variable
+}
+
+func (circuit *poseidon2Circuit) Define(api frontend.API) error {
+	h, err := hash.GetFieldHasher("POSEIDON2", api)
+	if err != nil {
+		return err
+	}
+	h.Write(circuit.Data[:]...)
+	result := h.Sum()
+	api.AssertIsEqual(result, circuit.ExpectedResult)
+	return nil
+}
+
+func TestPoseidon2All(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_381, ecc.BLS12_377, ecc.BW6_761} {
+		var circuit, validWitness, invalidWitness poseidon2Circuit
+
+		modulus := curve.ScalarField()
+		var data [10]big.Int
+		data[0].Sub(modulus, big.NewInt(1))
+		for i := 1; i < 10; i++ {
+			data[i].Add(&data[i-1], &data[i-1]).Mod(&data[i], modulus)
+		}
+
+		goHasher, err := NewNativeHasher(curve)
+		assert.NoError(err)
+		buf := make([]byte, goHasher.BlockSize())
+		for i := 0; i < 10; i++ {
+			data[i].FillBytes(buf)
+			_, err = goHasher.Write(buf)
+			assert.NoError(err)
+		}
+		expected := goHasher.Sum(nil)
+
+		for i := 0; i < 10; i++ {
+			validWitness.Data[i] = data[i].String()
+		}
+		validWitness.ExpectedResult = expected
+
+		for i := 0; i < 10; i++ {
+			invalidWitness.Data[i] = data[i].Sub(&data[i], big.NewInt(1)).String()
+		}
+		invalidWitness.ExpectedResult = expected
+
+		assert.CheckCircuit(&circuit,
+			test.WithValidAssignment(&validWitness),
+			test.WithInvalidAssignment(&invalidWitness),
+			test.WithCurves(curve))
+	}
+}
+
+type sumCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *sumCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Add(c.X, c.X), c.Y)
+	return nil
+}
+
+func TestNativeChallengeHash(t *testing.T) {
+	assert := test.NewAssert(t)
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &sumCircuit{})
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+	assert.NoError(err)
+	witness, err := frontend.NewWitness(&sumCircuit{X: 3, Y: 6}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	pubWitness, err := witness.Public()
+	assert.NoError(err)
+
+	proverHasher, err := NewNativeHasher(ecc.BN254)
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, witness, backend.WithProverChallengeHashFunction(proverHasher))
+	assert.NoError(err)
+	verifierHasher, err := NewNativeHasher(ecc.BN254)
+	assert.NoError(err)
+	err = plonk.Verify(proof, vk, pubWitness, backend.WithVerifierChallengeHashFunction(verifierHasher))
+	assert.NoError(err)
+	err = plonk.Verify(proof, vk, pubWitness)
+	assert.Error(err)
+}
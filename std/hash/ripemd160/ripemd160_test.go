@@ -0,0 +1,56 @@
+package ripemd160
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/ripemd160"
+)
+
+type ripemd160Circuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *ripemd160Circuit) Define(api frontend.API) error {
+	h, err := New(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("not %d bytes", len(c.Expected))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestRIPEMD160(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 3, 55, 56, 64, 100} {
+		assert.Run(func(assert *test.Assert) {
+			bts := make([]byte, l)
+			for i := range bts {
+				bts[i] = byte(i)
+			}
+			hf := ripemd160.New()
+			hf.Write(bts)
+			dgst := hf.Sum(nil)
+			circuit := ripemd160Circuit{In: make([]uints.U8, l), Expected: make([]uints.U8, 20)}
+			witness := ripemd160Circuit{In: uints.NewU8Array(bts), Expected: uints.NewU8Array(dgst)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("len=%d", l))
+	}
+}
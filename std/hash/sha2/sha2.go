@@ -1,7 +1,9 @@
 // Package sha2 implements SHA2 hash computation.
 //
 // This package extends the SHA2 permutation function [sha2] into a full SHA2
-// hash.
+// hash. The SHA-256 hash is constructed with [New] and the SHA-512, SHA-384 and
+// SHA-512/256 hashes, which use the 64-bit permutation, with [New512], [New384]
+// and [New512_256].
 package sha2
 
 import (
@@ -104,7 +106,7 @@ func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
 	last8BytesPos := d.api.Sub(totalLen, 8)
 
 	var dataLenBtyes [8]frontend.Variable
-	d.bigEndianPutUint64(dataLenBtyes[:], d.api.Mul(length, 8))
+	bigEndianPutUint64(d.api, dataLenBtyes[:], d.api.Mul(length, 8))
 
 	for i := range data {
 		isPaddingStartPos := d.api.IsZero(d.api.Sub(i, length))
@@ -160,9 +162,9 @@ func (d *digest) mod64(v frontend.Variable) frontend.Variable {
 	return lower
 }
 
-func (d *digest) bigEndianPutUint64(b []frontend.Variable, x frontend.Variable) {
-	bts := bits.ToBinary(d.api, x, bits.WithNbDigits(64))
+func bigEndianPutUint64(api frontend.API, b []frontend.Variable, x frontend.Variable) {
+	bts := bits.ToBinary(api, x, bits.WithNbDigits(64))
 	for i := 0; i < 8; i++ {
-		b[i] = bits.FromBinary(d.api, bts[(8-i-1)*8:(8-i)*8])
+		b[i] = bits.FromBinary(api, bts[(8-i-1)*8:(8-i)*8])
 	}
 }
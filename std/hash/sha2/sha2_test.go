@@ -1,12 +1,15 @@
 package sha2
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
 	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/test"
 )
@@ -89,3 +92,80 @@ func TestSHA2FixedLengthSum(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type sha512Circuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected []uints.U8
+
+	fixed bool
+}
+
+func (c *sha512Circuit) Define(api frontend.API) error {
+	var h hash.BinaryFixedLengthHasher
+	var err error
+	switch len(c.Expected) {
+	case 64:
+		h, err = New512(api)
+	case 48:
+		h, err = New384(api)
+	case 32:
+		h, err = New512_256(api)
+	default:
+		return fmt.Errorf("unsupported digest length %d", len(c.Expected))
+	}
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	var res []uints.U8
+	if c.fixed {
+		res = h.FixedLengthSum(c.Length)
+	} else {
+		res = h.Sum()
+	}
+	if len(res) != len(c.Expected) {
+		return fmt.Errorf("expected %d bytes, got %d", len(c.Expected), len(res))
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA512(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 310)
+	_, err := rand.Read(bts)
+	assert.NoError(err)
+	for _, tc := range []struct {
+		name string
+		sum  func([]byte) []byte
+	}{
+		{"SHA-512", func(b []byte) []byte { d := sha512.Sum512(b); return d[:] }},
+		{"SHA-384", func(b []byte) []byte { d := sha512.Sum384(b); return d[:] }},
+		{"SHA-512/256", func(b []byte) []byte { d := sha512.Sum512_256(b); return d[:] }},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			dgst := tc.sum(bts)
+			circuit := sha512Circuit{In: make([]uints.U8, len(bts)), Expected: make([]uints.U8, len(dgst))}
+			witness := sha512Circuit{In: uints.NewU8Array(bts), Length: len(bts), Expected: uints.NewU8Array(dgst)}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, tc.name)
+		// the padding crosses the block boundary for some lengths
+		for _, length := range []int{0, 111, 112, 200} {
+			assert.Run(func(assert *test.Assert) {
+				dgst := tc.sum(bts[:length])
+				circuit := sha512Circuit{In: make([]uints.U8, len(bts)), Expected: make([]uints.U8, len(dgst)), fixed: true}
+				witness := sha512Circuit{In: uints.NewU8Array(bts), Length: length, Expected: uints.NewU8Array(dgst)}
+				err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+			}, tc.name, fmt.Sprintf("length=%d", length))
+		}
+	}
+}
@@ -2,4 +2,8 @@
 // Keccak f-[1600] permutation function.
 //
 // Instances correspond golang.org/x/crypto/sha3, except SHA224, which is not x64 compatible.
+//
+// All instances implement [hash.BinaryFixedLengthHasher], so that the digest
+// can be computed over a prefix of the written bytes whose length is only
+// known at proving time, for example for RLP-encoded transactions.
 package sha3
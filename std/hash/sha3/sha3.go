@@ -1,11 +1,16 @@
 package sha3
 
 import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/cmp"
 	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/std/permutation/keccakf"
 )
 
 type digest struct {
+	api       frontend.API
 	uapi      *uints.BinaryField[uints.U64]
 	state     [25]uints.U64 // 1600 bits state: 25 x 64
 	in        []uints.U8    // input to be digested
@@ -32,6 +37,59 @@ func (d *digest) Sum() []uints.U8 {
 	return d.squeezeBlocks()
 }
 
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written.
+//
+// As the position of the padding depends on length, we pad the input for every
+// possible number of absorbed blocks and select the state after the block
+// which contains the end of the padding.
+func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	nbBlocks := len(d.in)/d.rate + 1
+	comparator := cmp.NewBoundedComparator(d.api, big.NewInt(int64(nbBlocks*d.rate+1)), false)
+	comparator.AssertIsLessEq(length, len(d.in))
+
+	// isLast[i] is 1 if the i-th block is the last block, i.e. the block
+	// containing the length-th byte.
+	isLast := make([]frontend.Variable, nbBlocks)
+	var prev frontend.Variable = 0
+	for i := range isLast {
+		isLess := comparator.IsLess(length, (i+1)*d.rate)
+		isLast[i] = d.api.Sub(isLess, prev)
+		prev = isLess
+	}
+
+	padded := make([]uints.U8, nbBlocks*d.rate)
+	for i := range padded {
+		// the bytes after the input are zero, the byte at position length is
+		// the domain separation byte and the last byte of the last block has
+		// the highest bit set. As the domain separation byte does not have the
+		// highest bit set, we can add the values instead of XOR.
+		var v frontend.Variable = 0
+		if i < len(d.in) {
+			v = d.api.Select(comparator.IsLess(i, length), d.in[i].Val, 0)
+		}
+		v = d.api.Add(v, d.api.Mul(d.api.IsZero(d.api.Sub(i, length)), d.dsbyte))
+		if (i+1)%d.rate == 0 {
+			v = d.api.Add(v, d.api.Mul(isLast[i/d.rate], 0x80))
+		}
+		padded[i] = uints.U8{Val: v}
+	}
+
+	blocks := d.composeBlocks(padded)
+	result := make([]uints.U8, d.outputLen)
+	for i := range result {
+		result[i] = uints.NewU8(0)
+	}
+	for i, block := range blocks {
+		d.absorbing([][]uints.U64{block})
+		output := d.squeezeBlocks()
+		for j := range result {
+			result[j].Val = d.api.Select(isLast[i], output[j].Val, result[j].Val)
+		}
+	}
+	return result
+}
+
 func (d *digest) padding() []uints.U8 {
 	padded := make([]uints.U8, len(d.in))
 	copy(padded[:], d.in[:])
@@ -0,0 +1,161 @@
+// Package tofield implements hashing of byte strings to field elements.
+//
+// The package implements expand_message_xmd with SHA-256 and hash_to_field as
+// defined in [RFC 9380]. The outputs correspond to ExpandMsgXmd and the Hash
+// methods of the field elements in gnark-crypto. The field elements are used
+// as inputs to the map-to-curve methods for hashing to elliptic curves.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+package tofield
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// securityLevel is the target security level k in bits of hash_to_field.
+	securityLevel = 128
+	// blockSize is the input block size of SHA-256 in bytes.
+	blockSize = 64
+	// digestSize is the output size of SHA-256 in bytes.
+	digestSize = 32
+)
+
+// ExpandMsgXmd expands msg to a slice of lenInBytes bytes using SHA-256 with
+// the domain separation tag dst.
+func ExpandMsgXmd(api frontend.API, msg []uints.U8, dst []byte, lenInBytes int) ([]uints.U8, error) {
+	ell := (lenInBytes + digestSize - 1) / digestSize
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, errors.New("invalid lenInBytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("invalid domain size (>255 bytes)")
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints: %w", err)
+	}
+	// DST_prime = DST ∥ I2OSP(len(DST), 1)
+	dstPrime := uints.NewU8Array(append(append([]byte{}, dst...), uint8(len(dst))))
+
+	// b₀ = H(Z_pad ∥ msg ∥ l_i_b_str ∥ I2OSP(0, 1) ∥ DST_prime)
+	h, err := sha2.New(api)
+	if err != nil {
+		return nil, fmt.Errorf("new sha2: %w", err)
+	}
+	h.Write(uints.NewU8Array(make([]byte, blockSize)))
+	h.Write(msg)
+	h.Write(uints.NewU8Array([]byte{uint8(lenInBytes >> 8), uint8(lenInBytes), 0}))
+	h.Write(dstPrime)
+	b0 := h.Sum()
+
+	// b₁ = H(b₀ ∥ I2OSP(1, 1) ∥ DST_prime)
+	h, err = sha2.New(api)
+	if err != nil {
+		return nil, fmt.Errorf("new sha2: %w", err)
+	}
+	h.Write(b0)
+	h.Write([]uints.U8{uints.NewU8(1)})
+	h.Write(dstPrime)
+	bi := h.Sum()
+
+	res := make([]uints.U8, 0, ell*digestSize)
+	res = append(res, bi...)
+	for i := 2; i <= ell; i++ {
+		// bᵢ = H(strxor(b₀, bᵢ₋₁) ∥ I2OSP(i, 1) ∥ DST_prime)
+		h, err = sha2.New(api)
+		if err != nil {
+			return nil, fmt.Errorf("new sha2: %w", err)
+		}
+		strxor := make([]uints.U8, 0, digestSize)
+		for j := 0; j < digestSize; j += 4 {
+			w := uapi.Xor(uapi.PackMSB(b0[j:j+4]...), uapi.PackMSB(bi[j:j+4]...))
+			strxor = append(strxor, uapi.UnpackMSB(w)...)
+		}
+		h.Write(strxor)
+		h.Write([]uints.U8{uints.NewU8(uint8(i))})
+		h.Write(dstPrime)
+		bi = h.Sum()
+		res = append(res, bi...)
+	}
+	return res[:lenInBytes], nil
+}
+
+// Emulated hashes msg to count elements of the emulated field given by T
+// with the domain separation tag dst.
+func Emulated[T emulated.FieldParams](api frontend.API, msg []uints.U8, dst []byte, count int) ([]*emulated.Element[T], error) {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new field: %w", err)
+	}
+	var fp T
+	L := elementLength(fp.Modulus())
+	uniformBytes, err := ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	// the big-endian integer is decomposed into chunks which fit into an
+	// element without overflow.
+	chunkSize := int(fp.NbLimbs()*fp.BitsPerLimb()) / 8
+	res := make([]*emulated.Element[T], count)
+	for i := range res {
+		tv := uniformBytes[i*L : (i+1)*L]
+		var acc *emulated.Element[T]
+		for end := len(tv); end > 0; end -= chunkSize {
+			start := max(end-chunkSize, 0)
+			bits := make([]frontend.Variable, 0, 8*(end-start))
+			for j := end - 1; j >= start; j-- {
+				bits = append(bits, api.ToBinary(tv[j].Val, 8)...)
+			}
+			chunk := f.FromBits(bits...)
+			if acc == nil {
+				acc = chunk
+				continue
+			}
+			// acc = acc + chunk·2^(8·(len(tv)-end))
+			shift := new(big.Int).Lsh(big.NewInt(1), uint(8*(len(tv)-end)))
+			shift.Mod(shift, fp.Modulus())
+			acc = f.Add(acc, f.Mul(chunk, f.NewElement(shift)))
+		}
+		res[i] = f.Reduce(acc)
+	}
+	return res, nil
+}
+
+// Native hashes msg to count elements of the native field with the domain
+// separation tag dst.
+func Native(api frontend.API, msg []uints.U8, dst []byte, count int) ([]frontend.Variable, error) {
+	modulus := api.Compiler().Field()
+	L := elementLength(modulus)
+	uniformBytes, err := ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]frontend.Variable, count)
+	for i := range res {
+		tv := uniformBytes[i*L : (i+1)*L]
+		// the big-endian integer is reduced by the native arithmetic
+		acc := frontend.Variable(0)
+		coef := big.NewInt(1)
+		for j := len(tv) - 1; j >= 0; j-- {
+			acc = api.Add(acc, api.Mul(tv[j].Val, coef))
+			coef = new(big.Int).Lsh(coef, 8)
+			coef.Mod(coef, modulus)
+		}
+		res[i] = acc
+	}
+	return res, nil
+}
+
+// elementLength returns the number of bytes L = ⌈(⌈log₂(p)⌉ + k) / 8⌉ used
+// for hashing to a single field element.
+func elementLength(modulus *big.Int) int {
+	return (modulus.BitLen() + securityLevel + 7) / 8
+}
@@ -0,0 +1,159 @@
+package tofield
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377fp "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12381fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	bn254fp "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+var (
+	testMsg = []byte("abcdef0123456789")
+	testDST = []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+)
+
+type expandMsgXmdCircuit struct {
+	Msg      []uints.U8
+	Expected []uints.U8
+	dst      []byte
+}
+
+func (c *expandMsgXmdCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	res, err := ExpandMsgXmd(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestExpandMsgXmd(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, lenInBytes := range []int{32, 80, 128} {
+		expected, err := hash.ExpandMsgXmd(testMsg, testDST, lenInBytes)
+		assert.NoError(err)
+		circuit := expandMsgXmdCircuit{
+			Msg:      make([]uints.U8, len(testMsg)),
+			Expected: make([]uints.U8, lenInBytes),
+			dst:      testDST,
+		}
+		witness := expandMsgXmdCircuit{
+			Msg:      uints.NewU8Array(testMsg),
+			Expected: uints.NewU8Array(expected),
+		}
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type emulatedCircuit[T emulated.FieldParams] struct {
+	Msg      []uints.U8
+	Expected []emulated.Element[T]
+	dst      []byte
+}
+
+func (c *emulatedCircuit[T]) Define(api frontend.API) error {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res, err := Emulated[T](api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		f.AssertIsEqual(res[i], &c.Expected[i])
+	}
+	return nil
+}
+
+func TestEmulatedBN254(t *testing.T) {
+	assert := test.NewAssert(t)
+	expected, err := bn254fp.Hash(testMsg, testDST, 2)
+	assert.NoError(err)
+	circuit := emulatedCircuit[emulated.BN254Fp]{
+		Msg:      make([]uints.U8, len(testMsg)),
+		Expected: make([]emulated.Element[emulated.BN254Fp], len(expected)),
+		dst:      testDST,
+	}
+	witness := emulatedCircuit[emulated.BN254Fp]{
+		Msg:      uints.NewU8Array(testMsg),
+		Expected: make([]emulated.Element[emulated.BN254Fp], len(expected)),
+	}
+	for i := range expected {
+		witness.Expected[i] = emulated.ValueOf[emulated.BN254Fp](expected[i])
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestEmulatedBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	expected, err := bls12381fp.Hash(testMsg, testDST, 2)
+	assert.NoError(err)
+	circuit := emulatedCircuit[emulated.BLS12381Fp]{
+		Msg:      make([]uints.U8, len(testMsg)),
+		Expected: make([]emulated.Element[emulated.BLS12381Fp], len(expected)),
+		dst:      testDST,
+	}
+	witness := emulatedCircuit[emulated.BLS12381Fp]{
+		Msg:      uints.NewU8Array(testMsg),
+		Expected: make([]emulated.Element[emulated.BLS12381Fp], len(expected)),
+	}
+	for i := range expected {
+		witness.Expected[i] = emulated.ValueOf[emulated.BLS12381Fp](expected[i])
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type nativeCircuit struct {
+	Msg      []uints.U8
+	Expected []frontend.Variable
+	dst      []byte
+}
+
+func (c *nativeCircuit) Define(api frontend.API) error {
+	res, err := Native(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestNative(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the scalar field of BW6-761 is the base field of BLS12-377
+	expected, err := bls12377fp.Hash(testMsg, testDST, 2)
+	assert.NoError(err)
+	circuit := nativeCircuit{
+		Msg:      make([]uints.U8, len(testMsg)),
+		Expected: make([]frontend.Variable, len(expected)),
+		dst:      testDST,
+	}
+	witness := nativeCircuit{
+		Msg:      uints.NewU8Array(testMsg),
+		Expected: make([]frontend.Variable, len(expected)),
+	}
+	for i := range expected {
+		witness.Expected[i] = expected[i].String()
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+}
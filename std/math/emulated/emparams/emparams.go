@@ -226,6 +226,40 @@ type P384Fr struct{ sixLimbPrimeField }
 
 func (P384Fr) Modulus() *big.Int { return elliptic.P384().Params().N }
 
+// Ed25519Fp provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed (base 16)
+//	57896044618658097711785492504343953926634992332820282019728792003956564819949 (base 10)
+//
+// This is the base field of the Ed25519 (also Curve25519) curve.
+type Ed25519Fp struct{ fourLimbPrimeField }
+
+func (Ed25519Fp) Modulus() *big.Int { return ed25519Fp }
+
+// Ed25519Fr provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed (base 16)
+//	7237005577332262213973186563042994240857116359379907606001950938285454250989 (base 10)
+//
+// This is the order of the prime-order subgroup of the Ed25519 (also
+// Curve25519) curve.
+type Ed25519Fr struct{ fourLimbPrimeField }
+
+func (Ed25519Fr) Modulus() *big.Int { return ed25519Fr }
+
+var (
+	ed25519Fp, _ = new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+	ed25519Fr, _ = new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+)
+
 // BW6761Fp provides type parametrization for field emulation:
 //   - limbs: 12
 //   - limb width: 64 bits
@@ -17,6 +17,7 @@ import (
 //   - [BLS12381Fp] and [BLS12381Fr]
 //   - [P256Fp] and [P256Fr]
 //   - [P384Fp] and [P384Fr]
+//   - [Ed25519Fp] and [Ed25519Fr]
 type FieldParams interface {
 	NbLimbs() uint     // number of limbs to represent field element
 	BitsPerLimb() uint // number of bits per limb. Top limb may contain less than limbSize bits.
@@ -39,4 +40,6 @@ type (
 	P384Fr      = emparams.P384Fr
 	BW6761Fp    = emparams.BW6761Fp
 	BW6761Fr    = emparams.BW6761Fr
+	Ed25519Fp   = emparams.Ed25519Fp
+	Ed25519Fr   = emparams.Ed25519Fr
 )
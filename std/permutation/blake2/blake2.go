@@ -0,0 +1,107 @@
+// Package blake2 implements the BLAKE2b and BLAKE2s compression functions.
+//
+// The compression functions are defined in RFC 7693. The BLAKE2b compression
+// function with configurable number of rounds is also used in the EIP-152
+// BLAKE2F precompile. For hashing arbitrary bytes use
+// [github.com/consensys/gnark/std/hash/blake2b] and
+// [github.com/consensys/gnark/std/hash/blake2s].
+package blake2
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// IV2b is the initialization vector of BLAKE2b.
+var IV2b = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+// IV2s is the initialization vector of BLAKE2s.
+var IV2s = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+const (
+	// Rounds2b is the number of rounds of the BLAKE2b compression function.
+	Rounds2b = 12
+	// Rounds2s is the number of rounds of the BLAKE2s compression function.
+	Rounds2s = 10
+)
+
+var sigma = [10][16]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// rotation constants of the mixing function G
+var (
+	rot2b = [4]int{32, 24, 16, 63}
+	rot2s = [4]int{16, 12, 8, 7}
+)
+
+// Compress2b computes the BLAKE2b compression function F with the given number
+// of rounds. The state h, message block m and offset counter t are given as
+// 64-bit words. The final block indicator f must be boolean.
+func Compress2b(uapi *uints.BinaryField[uints.U64], rounds int, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, f frontend.Variable) [8]uints.U64 {
+	iv := uints.NewU64Array(IV2b[:])
+	return compress(uapi, rot2b, iv, uints.NewU64(1), rounds, h, m, t, f)
+}
+
+// Compress2s computes the BLAKE2s compression function F with the given number
+// of rounds. The state h, message block m and offset counter t are given as
+// 32-bit words. The final block indicator f must be boolean.
+func Compress2s(uapi *uints.BinaryField[uints.U32], rounds int, h [8]uints.U32, m [16]uints.U32, t [2]uints.U32, f frontend.Variable) [8]uints.U32 {
+	iv := uints.NewU32Array(IV2s[:])
+	return compress(uapi, rot2s, iv, uints.NewU32(1), rounds, h, m, t, f)
+}
+
+func compress[T uints.U32 | uints.U64](uapi *uints.BinaryField[T], rot [4]int, iv []T, one T, rounds int, h [8]T, m [16]T, t [2]T, f frontend.Variable) [8]T {
+	var v [16]T
+	copy(v[:8], h[:])
+	copy(v[8:], iv)
+	v[12] = uapi.Xor(v[12], t[0])
+	v[13] = uapi.Xor(v[13], t[1])
+	// invert all bits of v[14] if the final block indicator is set. For
+	// boolean f, the mask -f is either all zeros or all ones.
+	mask := uapi.Add(uapi.Not(uapi.ValueOf(f)), one)
+	v[14] = uapi.Xor(v[14], mask)
+
+	for i := 0; i < rounds; i++ {
+		s := sigma[i%10]
+		mix(uapi, rot, &v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		mix(uapi, rot, &v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		mix(uapi, rot, &v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		mix(uapi, rot, &v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		mix(uapi, rot, &v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		mix(uapi, rot, &v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		mix(uapi, rot, &v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		mix(uapi, rot, &v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] = uapi.Xor(h[i], v[i], v[i+8])
+	}
+	return h
+}
+
+// mix is the mixing function G.
+func mix[T uints.U32 | uints.U64](uapi *uints.BinaryField[T], rot [4]int, v *[16]T, a, b, c, d int, x, y T) {
+	v[a] = uapi.Add(v[a], v[b], x)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -rot[0])
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -rot[1])
+	v[a] = uapi.Add(v[a], v[b], y)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -rot[2])
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -rot[3])
+}
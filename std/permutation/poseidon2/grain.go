@@ -0,0 +1,65 @@
+package poseidon2
+
+import "math/big"
+
+// grain is the Grain LFSR used by the reference implementation of Poseidon and
+// Poseidon2 to sample the round constants (generate_parameters_grain.sage).
+type grain struct {
+	state [80]uint8
+}
+
+// newGrain returns the LFSR for an instance over a prime field of nbBits bits
+// with the S-box x -> x^d.
+func newGrain(nbBits, width, nbFullRounds, nbPartialRounds int) *grain {
+	var g grain
+	i := 0
+	write := func(v, nbBits int) {
+		for j := nbBits - 1; j >= 0; j-- {
+			g.state[i] = uint8(v>>j) & 1
+			i++
+		}
+	}
+	write(1, 2) // prime field
+	write(0, 4) // S-box x^d
+	write(nbBits, 12)
+	write(width, 12)
+	write(nbFullRounds, 10)
+	write(nbPartialRounds, 10)
+	write(1<<30-1, 30)
+	for j := 0; j < 160; j++ {
+		g.step()
+	}
+	return &g
+}
+
+func (g *grain) step() uint8 {
+	b := g.state[62] ^ g.state[51] ^ g.state[38] ^ g.state[23] ^ g.state[13] ^ g.state[0]
+	copy(g.state[:], g.state[1:])
+	g.state[79] = b
+	return b
+}
+
+// bit returns the next output bit. The bits are produced in pairs, the second
+// bit is output only if the first one is 1.
+func (g *grain) bit() uint {
+	for {
+		if b1, b2 := g.step(), g.step(); b1 == 1 {
+			return uint(b2)
+		}
+	}
+}
+
+// fieldElement samples an element of the field by rejection, reading the bits
+// most significant first.
+func (g *grain) fieldElement(modulus *big.Int) *big.Int {
+	nbBits := modulus.BitLen()
+	for {
+		v := new(big.Int)
+		for i := 0; i < nbBits; i++ {
+			v.Lsh(v, 1).SetBit(v, 0, g.bit())
+		}
+		if v.Cmp(modulus) < 0 {
+			return v
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package poseidon2
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+var (
+	// ErrInvalidWidth is returned when the permutation width is not supported.
+	ErrInvalidWidth = errors.New("poseidon2: width must be 2 or 3")
+	// ErrInvalidSizebuffer is returned when the state length does not match the
+	// permutation width.
+	ErrInvalidSizebuffer = errors.New("poseidon2: state length does not match width")
+	// ErrCompressionWidth is returned when the compression function is used
+	// with a permutation which width is not 2.
+	ErrCompressionWidth = errors.New("poseidon2: compression requires width 2")
+)
+
+// Parameters defines a Poseidon2 instance. The round keys are derived from the
+// other parameters as in the reference implementation, see [NewParameters].
+//
+// The external matrix is circ(2, 1) for width 2 and circ(2, 1, 1) for width
+// 3. The internal matrix is [[2, 1], [1, 3]] for width 2 and [[2, 1, 1], [1,
+// 2, 1], [1, 1, 3]] for width 3, as defined in the Poseidon2 paper
+// (https://eprint.iacr.org/2023/323).
+type Parameters struct {
+	// Modulus is the field the permutation is defined over.
+	Modulus *big.Int
+	// Width is the size of the state t.
+	Width int
+	// DegreeSBox is the exponent d of the S-box x -> x^d.
+	DegreeSBox int
+	// NbFullRounds is the number of full rounds rF. Half of them are applied
+	// before and half after the partial rounds.
+	NbFullRounds int
+	// NbPartialRounds is the number of partial rounds rP.
+	NbPartialRounds int
+	// RoundKeys holds the round constants. For full rounds it contains Width
+	// elements, for partial rounds only one.
+	RoundKeys [][]*big.Int
+}
+
+// NewParameters returns a new Poseidon2 instance over the field defined by
+// modulus. The round keys are sampled with the Grain LFSR as in the reference
+// implementation (https://github.com/HorizenLabs/poseidon2), so that the
+// instance matches the other implementations with the same parameters.
+func NewParameters(modulus *big.Int, width, degreeSBox, nbFullRounds, nbPartialRounds int) (*Parameters, error) {
+	if width != 2 && width != 3 {
+		return nil, ErrInvalidWidth
+	}
+	if nbFullRounds%2 != 0 {
+		return nil, errors.New("poseidon2: number of full rounds must be even")
+	}
+	p := &Parameters{
+		Modulus:         new(big.Int).Set(modulus),
+		Width:           width,
+		DegreeSBox:      degreeSBox,
+		NbFullRounds:    nbFullRounds,
+		NbPartialRounds: nbPartialRounds,
+	}
+	p.initRoundKeys()
+	return p, nil
+}
+
+// GetDefaultParameters returns the default parameters of width 2 for the scalar
+// field of the given curve. The parameters target 128 bits of security.
+func GetDefaultParameters(curve ecc.ID) (*Parameters, error) {
+	switch curve {
+	case ecc.BN254:
+		return NewParameters(curve.ScalarField(), 2, 5, 6, 50)
+	case ecc.BLS12_381:
+		return NewParameters(curve.ScalarField(), 2, 5, 6, 50)
+	case ecc.BLS12_377:
+		return NewParameters(curve.ScalarField(), 2, 17, 6, 26)
+	case ecc.BW6_761:
+		return NewParameters(curve.ScalarField(), 2, 5, 6, 50)
+	default:
+		return nil, fmt.Errorf("poseidon2: no default parameters for curve %s", curve)
+	}
+}
+
+// String returns the description of the instance.
+func (p *Parameters) String() string {
+	return fmt.Sprintf("Poseidon2[t=%d,rF=%d,rP=%d,d=%d]", p.Width, p.NbFullRounds, p.NbPartialRounds, p.DegreeSBox)
+}
+
+// initRoundKeys samples the round keys in order, Width per full round and one
+// per partial round.
+func (p *Parameters) initRoundKeys() {
+	g := newGrain(p.Modulus.BitLen(), p.Width, p.NbFullRounds, p.NbPartialRounds)
+	next := func() *big.Int {
+		return g.fieldElement(p.Modulus)
+	}
+	rf := p.NbFullRounds / 2
+	p.RoundKeys = make([][]*big.Int, p.NbFullRounds+p.NbPartialRounds)
+	for i := range p.RoundKeys {
+		n := 1
+		if i < rf || i >= rf+p.NbPartialRounds {
+			n = p.Width
+		}
+		p.RoundKeys[i] = make([]*big.Int, n)
+		for j := range p.RoundKeys[i] {
+			p.RoundKeys[i][j] = next()
+		}
+	}
+}
+
+// Permutation applies the permutation in place on the native state. It is the
+// out-of-circuit counterpart of [Permutation.Permutation].
+func (p *Parameters) Permutation(state []*big.Int) error {
+	if len(state) != p.Width {
+		return ErrInvalidSizebuffer
+	}
+	for i := range state {
+		state[i].Mod(state[i], p.Modulus)
+	}
+	p.matMulExternal(state)
+	rf := p.NbFullRounds / 2
+	for i := 0; i < rf; i++ {
+		p.fullRound(state, i)
+	}
+	for i := rf; i < rf+p.NbPartialRounds; i++ {
+		state[0].Add(state[0], p.RoundKeys[i][0])
+		p.sBox(state[0])
+		p.matMulInternal(state)
+	}
+	for i := rf + p.NbPartialRounds; i < p.NbFullRounds+p.NbPartialRounds; i++ {
+		p.fullRound(state, i)
+	}
+	return nil
+}
+
+// Compress computes the two-to-one compression function Perm(left, right)[1]
+// + right using a permutation of width 2. It is the out-of-circuit counterpart
+// of [Permutation.Compress].
+func (p *Parameters) Compress(left, right *big.Int) (*big.Int, error) {
+	if p.Width != 2 {
+		return nil, ErrCompressionWidth
+	}
+	state := []*big.Int{new(big.Int).Set(left), new(big.Int).Set(right)}
+	if err := p.Permutation(state); err != nil {
+		return nil, err
+	}
+	res := state[1].Add(state[1], right)
+	return res.Mod(res, p.Modulus), nil
+}
+
+func (p *Parameters) fullRound(state []*big.Int, round int) {
+	for j := range state {
+		state[j].Add(state[j], p.RoundKeys[round][j])
+		p.sBox(state[j])
+	}
+	p.matMulExternal(state)
+}
+
+func (p *Parameters) sBox(x *big.Int) {
+	x.Exp(x, big.NewInt(int64(p.DegreeSBox)), p.Modulus)
+}
+
+func (p *Parameters) matMulExternal(state []*big.Int) {
+	// circ(2, 1) and circ(2, 1, 1) are I + J, so we add the sum to every
+	// element.
+	s := new(big.Int)
+	for i := range state {
+		s.Add(s, state[i])
+	}
+	for i := range state {
+		state[i].Add(state[i], s).Mod(state[i], p.Modulus)
+	}
+}
+
+func (p *Parameters) matMulInternal(state []*big.Int) {
+	// the internal matrix is diag(1, .., 1, 2) + J.
+	s := new(big.Int)
+	for i := range state {
+		s.Add(s, state[i])
+	}
+	last := len(state) - 1
+	state[last].Lsh(state[last], 1)
+	for i := range state {
+		state[i].Add(state[i], s).Mod(state[i], p.Modulus)
+	}
+}
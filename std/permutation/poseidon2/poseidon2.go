@@ -0,0 +1,126 @@
+// Package poseidon2 implements the Poseidon2 permutation.
+//
+// This package exposes the permutation primitive and the two-to-one
+// compression function built on top of it. For hashing arbitrary number of
+// field elements use [github.com/consensys/gnark/std/hash/poseidon2], which
+// applies Merkle-Damgård construction over the compression function.
+//
+// The permutation is defined in https://eprint.iacr.org/2023/323. The native
+// counterpart of the permutation is implemented by [Parameters.Permutation].
+package poseidon2
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+var errParamsFieldMismatch = errors.New("poseidon2: parameters modulus does not match native field")
+
+// Permutation is the in-circuit Poseidon2 permutation.
+type Permutation struct {
+	api    frontend.API
+	params *Parameters
+}
+
+// NewPoseidon2 returns a new Poseidon2 permutation using the default
+// parameters for the native field. See [GetDefaultParameters] for the
+// supported fields.
+func NewPoseidon2(api frontend.API) (*Permutation, error) {
+	params, err := GetDefaultParameters(utils.FieldToCurve(api.Compiler().Field()))
+	if err != nil {
+		return nil, err
+	}
+	return NewPoseidon2FromParameters(api, params)
+}
+
+// NewPoseidon2FromParameters returns a new Poseidon2 permutation using the
+// given parameters. The modulus of the parameters must match the native field.
+func NewPoseidon2FromParameters(api frontend.API, params *Parameters) (*Permutation, error) {
+	if params.Modulus.Cmp(api.Compiler().Field()) != 0 {
+		return nil, errParamsFieldMismatch
+	}
+	if params.Width != 2 && params.Width != 3 {
+		return nil, ErrInvalidWidth
+	}
+	return &Permutation{api: api, params: params}, nil
+}
+
+// Permutation applies the permutation in place on the state. The length of the
+// state must match the width of the parameters.
+func (p *Permutation) Permutation(state []frontend.Variable) error {
+	if len(state) != p.params.Width {
+		return ErrInvalidSizebuffer
+	}
+	p.matMulExternal(state)
+	rf := p.params.NbFullRounds / 2
+	for i := 0; i < rf; i++ {
+		p.fullRound(state, i)
+	}
+	for i := rf; i < rf+p.params.NbPartialRounds; i++ {
+		state[0] = p.sBox(p.api.Add(state[0], p.params.RoundKeys[i][0]))
+		p.matMulInternal(state)
+	}
+	for i := rf + p.params.NbPartialRounds; i < p.params.NbFullRounds+p.params.NbPartialRounds; i++ {
+		p.fullRound(state, i)
+	}
+	return nil
+}
+
+// Compress computes the two-to-one compression function Perm(left, right)[1]
+// + right. It panics if the width of the permutation is not 2.
+func (p *Permutation) Compress(left, right frontend.Variable) frontend.Variable {
+	if p.params.Width != 2 {
+		panic(ErrCompressionWidth)
+	}
+	state := []frontend.Variable{left, right}
+	if err := p.Permutation(state); err != nil {
+		panic(err) // can't error, width is checked
+	}
+	return p.api.Add(state[1], right)
+}
+
+func (p *Permutation) fullRound(state []frontend.Variable, round int) {
+	for j := range state {
+		state[j] = p.sBox(p.api.Add(state[j], p.params.RoundKeys[round][j]))
+	}
+	p.matMulExternal(state)
+}
+
+// sBox computes x^d using square-and-multiply.
+func (p *Permutation) sBox(x frontend.Variable) frontend.Variable {
+	d := p.params.DegreeSBox
+	var res frontend.Variable
+	acc := x
+	for d > 0 {
+		if d&1 == 1 {
+			if res == nil {
+				res = acc
+			} else {
+				res = p.api.Mul(res, acc)
+			}
+		}
+		d >>= 1
+		if d > 0 {
+			acc = p.api.Mul(acc, acc)
+		}
+	}
+	return res
+}
+
+func (p *Permutation) matMulExternal(state []frontend.Variable) {
+	s := p.api.Add(state[0], state[1], state[2:]...)
+	for i := range state {
+		state[i] = p.api.Add(state[i], s)
+	}
+}
+
+func (p *Permutation) matMulInternal(state []frontend.Variable) {
+	s := p.api.Add(state[0], state[1], state[2:]...)
+	last := len(state) - 1
+	for i := 0; i < last; i++ {
+		state[i] = p.api.Add(state[i], s)
+	}
+	state[last] = p.api.Add(p.api.Mul(state[last], 2), s)
+}
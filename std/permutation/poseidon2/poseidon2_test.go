@@ -0,0 +1,91 @@
+package poseidon2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type permutationCircuit struct {
+	params   *Parameters
+	Input    []frontend.Variable
+	Expected []frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	p, err := NewPoseidon2FromParameters(api, c.params)
+	if err != nil {
+		return err
+	}
+	state := make([]frontend.Variable, len(c.Input))
+	copy(state, c.Input)
+	if err := p.Permutation(state); err != nil {
+		return err
+	}
+	for i := range state {
+		api.AssertIsEqual(state[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_381, ecc.BLS12_377, ecc.BW6_761} {
+		def, err := GetDefaultParameters(curve)
+		assert.NoError(err)
+		for _, width := range []int{2, 3} {
+			params, err := NewParameters(curve.ScalarField(), width, def.DegreeSBox, def.NbFullRounds, def.NbPartialRounds)
+			assert.NoError(err)
+			input := make([]*big.Int, width)
+			state := make([]*big.Int, width)
+			for i := range input {
+				input[i] = big.NewInt(int64(i + 1))
+				state[i] = big.NewInt(int64(i + 1))
+			}
+			assert.NoError(params.Permutation(state))
+			circuit := permutationCircuit{params: params, Input: make([]frontend.Variable, width), Expected: make([]frontend.Variable, width)}
+			assignment := permutationCircuit{Input: make([]frontend.Variable, width), Expected: make([]frontend.Variable, width)}
+			for i := range input {
+				assignment.Input[i] = input[i]
+				assignment.Expected[i] = state[i]
+			}
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(curve))
+		}
+	}
+}
+
+func TestInvalidParameters(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := NewParameters(ecc.BN254.ScalarField(), 4, 5, 6, 50)
+	assert.ErrorIs(err, ErrInvalidWidth)
+	params, err := NewParameters(ecc.BN254.ScalarField(), 3, 5, 6, 50)
+	assert.NoError(err)
+	_, err = params.Compress(big.NewInt(1), big.NewInt(2))
+	assert.ErrorIs(err, ErrCompressionWidth)
+}
+
+func TestPermutationKnownAnswer(t *testing.T) {
+	assert := test.NewAssert(t)
+	// BN254 instance of width 3 of the reference implementation
+	// (https://github.com/HorizenLabs/poseidon2), with its test vector.
+	params, err := NewParameters(ecc.BN254.ScalarField(), 3, 5, 8, 56)
+	assert.NoError(err)
+	state := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+	expected := make([]*big.Int, 3)
+	for i, s := range []string{
+		"0x0bb61d24daca55eebcb1929a82650f328134334da98ea4f847f760054f4a3033",
+		"0x303b6f7c86d043bfcbcc80214f26a30277a15d3f74ca654992defe7ff8d03570",
+		"0x1ed25194542b12eef8617361c3ba7c52e660b145994427cc86296242cf766ec8",
+	} {
+		expected[i], _ = new(big.Int).SetString(s, 0)
+	}
+	assert.NoError(params.Permutation(state))
+	assert.Equal(expected, state)
+
+	circuit := permutationCircuit{params: params, Input: make([]frontend.Variable, 3), Expected: make([]frontend.Variable, 3)}
+	assignment := permutationCircuit{Input: []frontend.Variable{0, 1, 2}, Expected: []frontend.Variable{expected[0], expected[1], expected[2]}}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&assignment), test.WithCurves(ecc.BN254))
+}
@@ -1,6 +1,8 @@
 package sha2_test
 
 import (
+	"crypto/sha512"
+	"encoding/binary"
 	"math/bits"
 	"math/rand"
 	"testing"
@@ -114,3 +116,51 @@ func TestBlockGeneric(t *testing.T) {
 	err := test.IsSolved(&circuitBlock{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type circuitBlock512 struct {
+	CurrentDig [8]uints.U64
+	In         [128]uints.U8
+	Expected   [8]uints.U64
+}
+
+func (c *circuitBlock512) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	res := sha2.Permute512(uapi, c.CurrentDig, c.In)
+	for i := range c.Expected {
+		uapi.AssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBlock512(t *testing.T) {
+	assert := test.NewAssert(t)
+	s := rand.New(rand.NewSource(time.Now().Unix())) //nolint G404, test code
+	// a message of 100 bytes fits into a single padded block, so the digest
+	// is the output of a single permutation on the initial state.
+	msg := make([]byte, 100)
+	for i := range msg {
+		msg[i] = byte(s.Uint32() & 0xff)
+	}
+	var in [128]byte
+	copy(in[:], msg)
+	in[len(msg)] = 0x80
+	binary.BigEndian.PutUint64(in[120:], uint64(8*len(msg)))
+	dgst := sha512.Sum512(msg)
+	iv := []uint64{
+		0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+		0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+	}
+	witness := circuitBlock512{}
+	for i := range witness.CurrentDig {
+		witness.CurrentDig[i] = uints.NewU64(iv[i])
+		witness.Expected[i] = uints.NewU64(binary.BigEndian.Uint64(dgst[8*i:]))
+	}
+	for i := range in {
+		witness.In[i] = uints.NewU8(in[i])
+	}
+	err := test.IsSolved(&circuitBlock512{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
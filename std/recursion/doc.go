@@ -1,2 +1,33 @@
 // Package recursion provides in-circuit verifiers for different proofs systems.
+//
+// # Hash functions
+//
+// The in-circuit verifiers derive the Fiat-Shamir challenges, hash the
+// commitments to the field and fold the KZG opening proofs using a
+// SNARK-friendly hash function over the native field of the outer circuit. The
+// native prover and verifier have to be configured to use the same hash
+// function. The native hash function is returned by [NewShort] and its
+// in-circuit counterpart by [NewHash] and [NewTranscript]. The underlying hash
+// function is chosen with [WithHashFunction]. The following combinations of
+// outer circuit fields and hash functions are supported:
+//
+//	| outer field | MiMC | Poseidon2 |
+//	|-------------|------|-----------|
+//	| BN254       | yes  | yes       |
+//	| BLS12-381   | yes  | yes       |
+//	| BLS12-377   | yes  | yes       |
+//	| BW6-761     | yes  | yes       |
+//	| BLS24-315   | yes  | no        |
+//	| BLS24-317   | yes  | no        |
+//	| BW6-633     | yes  | no        |
+//
+// The native hash functions for BLS12-378 and BW6-756 are available only with
+// MiMC and only out-of-circuit as gnark does not compile circuits over these
+// fields.
+//
+// The outputs of the hash functions are truncated to fit into the scalar field
+// of the inner curve, so any inner curve can be used with any outer field. The
+// native options returned by [github.com/consensys/gnark/std/recursion/plonk.GetNativeProverOptions]
+// and [github.com/consensys/gnark/std/recursion/groth16.GetNativeProverOptions]
+// accept the same hash options and set the hash functions of the backends.
 package recursion
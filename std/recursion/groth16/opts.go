@@ -14,6 +14,7 @@ type verifierCfg struct {
 	algopt             []algopts.AlgebraOption
 	pedopt             []pedersen.VerifierOption
 	forceSubgroupCheck bool
+	hashOpts           []recursion.HashOption
 }
 
 // VerifierOption allows to modify the behaviour of Groth16 verifier.
@@ -36,6 +37,17 @@ func WithSubgroupCheck() VerifierOption {
 	}
 }
 
+// WithHashFunction sets the hash function used for hashing the commitments to
+// field. The native prover must use the same hash function, see
+// [GetNativeProverOptions]. If not set, then MiMC is used. See
+// [recursion.HashFunction] for the supported hash functions.
+func WithHashFunction(hf recursion.HashFunction) VerifierOption {
+	return func(cfg *verifierCfg) error {
+		cfg.hashOpts = append(cfg.hashOpts, recursion.WithHashFunction(hf))
+		return nil
+	}
+}
+
 func newCfg(opts ...VerifierOption) (*verifierCfg, error) {
 	cfg := new(verifierCfg)
 	for i := range opts {
@@ -47,10 +59,13 @@ func newCfg(opts ...VerifierOption) (*verifierCfg, error) {
 }
 
 // GetNativeProverOptions returns Groth16 prover options for the native prover
-// to initialize the configuration suitable for in-circuit verification.
-func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
+// to initialize the configuration suitable for in-circuit verification. The
+// hash options define the hash function used for hashing the commitments to
+// field. The in-circuit verifier must be configured with the same hash function
+// using [WithHashFunction].
+func GetNativeProverOptions(outer, field *big.Int, opts ...recursion.HashOption) backend.ProverOption {
 	return func(pc *backend.ProverConfig) error {
-		htfProverHasher, err := recursion.NewShort(outer, field)
+		htfProverHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
@@ -64,10 +79,11 @@ func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
 }
 
 // GetNativeVerifierOptions returns Groth16 verifier options to initialize the
-// configuration to be compatible with in-circuit verification.
-func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
+// configuration to be compatible with in-circuit verification. The hash
+// options must be the same as given to [GetNativeProverOptions].
+func GetNativeVerifierOptions(outer, field *big.Int, opts ...recursion.HashOption) backend.VerifierOption {
 	return func(vc *backend.VerifierConfig) error {
-		htfVerifierHasher, err := recursion.NewShort(outer, field)
+		htfVerifierHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
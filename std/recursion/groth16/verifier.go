@@ -586,7 +586,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El,
 	if err != nil {
 		return fmt.Errorf("apply options: %w", err)
 	}
-	hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true)
+	hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true, opt.hashOpts...)
 	if err != nil {
 		return fmt.Errorf("hash to field: %w", err)
 	}
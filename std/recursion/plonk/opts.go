@@ -5,22 +5,26 @@ import (
 	"math/big"
 
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/std/commitments/kzg"
 	"github.com/consensys/gnark/std/recursion"
 )
 
 // GetNativeProverOptions returns PLONK prover options for the native prover to
-// initialize the configuration suitable for in-circuit verification.
-func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
+// initialize the configuration suitable for in-circuit verification. The hash
+// options define the hash function used for the Fiat-Shamir challenges, KZG
+// folding and hashing to field. The in-circuit verifier must be configured
+// with the same hash function using [WithHashFunction].
+func GetNativeProverOptions(outer, field *big.Int, opts ...recursion.HashOption) backend.ProverOption {
 	return func(pc *backend.ProverConfig) error {
-		fsProverHasher, err := recursion.NewShort(outer, field)
+		fsProverHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get prover fs hash: %w", err)
 		}
-		kzgProverHasher, err := recursion.NewShort(outer, field)
+		kzgProverHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get prover kzg hash: %w", err)
 		}
-		htfProverHasher, err := recursion.NewShort(outer, field)
+		htfProverHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
@@ -42,18 +46,19 @@ func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
 }
 
 // GetNativeVerifierOptions returns PLONK verifier options to initialize the
-// configuration to be compatible with in-circuit verification.
-func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
+// configuration to be compatible with in-circuit verification. The hash
+// options must be the same as given to [GetNativeProverOptions].
+func GetNativeVerifierOptions(outer, field *big.Int, opts ...recursion.HashOption) backend.VerifierOption {
 	return func(vc *backend.VerifierConfig) error {
-		fsVerifierHasher, err := recursion.NewShort(outer, field)
+		fsVerifierHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get verifier fs hash: %w", err)
 		}
-		kzgVerifierHasher, err := recursion.NewShort(outer, field)
+		kzgVerifierHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get verifier kzg hash: %w", err)
 		}
-		htfVerifierHasher, err := recursion.NewShort(outer, field)
+		htfVerifierHasher, err := recursion.NewShort(outer, field, opts...)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
@@ -75,6 +80,8 @@ func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
 
 type verifierCfg struct {
 	withCompleteArithmetic bool
+	hashOpts               []recursion.HashOption
+	kzgOpts                []kzg.VerifierOption
 }
 
 // VerifierOption allows to modify the behaviour of PLONK verifier.
@@ -94,6 +101,19 @@ func WithCompleteArithmetic() VerifierOption {
 	}
 }
 
+// WithHashFunction sets the hash function used for deriving the Fiat-Shamir
+// challenges, folding the KZG opening proofs and hashing the commitments to
+// field. The native prover must use the same hash function, see
+// [GetNativeProverOptions]. If not set, then MiMC is used. See
+// [recursion.HashFunction] for the supported hash functions.
+func WithHashFunction(hf recursion.HashFunction) VerifierOption {
+	return func(cfg *verifierCfg) error {
+		cfg.hashOpts = append(cfg.hashOpts, recursion.WithHashFunction(hf))
+		cfg.kzgOpts = append(cfg.kzgOpts, kzg.WithHashFunction(hf))
+		return nil
+	}
+}
+
 func newCfg(opts ...VerifierOption) (*verifierCfg, error) {
 	cfg := new(verifierCfg)
 	for i := range opts {
@@ -788,7 +788,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 		return nil, nil, nil, fmt.Errorf("BSB22 commitment number mismatch")
 	}
 
-	fs, err := recursion.NewTranscript(v.api, fr.Modulus(), []string{"gamma", "beta", "alpha", "zeta"})
+	fs, err := recursion.NewTranscript(v.api, fr.Modulus(), []string{"gamma", "beta", "alpha", "zeta"}, cfg.hashOpts...)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("init new transcript: %w", err)
 	}
@@ -863,7 +863,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 	}
 
 	if len(vk.CommitmentConstraintIndexes) > 0 {
-		hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true)
+		hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true, cfg.hashOpts...)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -1012,7 +1012,11 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 	digestsToFold[3] = proof.LRO[2]
 	digestsToFold[4] = vk.S[0]
 	digestsToFold[5] = vk.S[1]
-	foldedProof, foldedDigest, err := v.kzg.FoldProof(
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	foldedProof, foldedDigest, err := kzgVerifier.FoldProof(
 		digestsToFold,
 		proof.BatchedProof,
 		*zeta,
@@ -1034,12 +1038,20 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) PrepareVerification(vk VerifyingKey[FR,
 // verifying key.
 func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[FR, G1El, G2El], proof Proof[FR, G1El, G2El], witness Witness[FR], opts ...VerifierOption) error {
 
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
+	}
 	commitments, proofs, points, err := v.PrepareVerification(vk, proof, witness, opts...)
 	if err != nil {
 		return err
 	}
 
-	err = v.kzg.BatchVerifyMultiPoints(commitments, proofs, points, vk.Kzg)
+	err = kzgVerifier.BatchVerifyMultiPoints(commitments, proofs, points, vk.Kzg)
 	if err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
@@ -1055,7 +1067,15 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertSameProofs(vk VerifyingKey[FR, G1
 		return fmt.Errorf("no proofs to check")
 	}
 	if len(proofs) == 1 {
-		return v.AssertProof(vk, proofs[0], witnesses[0])
+		return v.AssertProof(vk, proofs[0], witnesses[0], opts...)
+	}
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
 	}
 	var foldedDigests []kzg.Commitment[G1El]
 	var foldedProofs []kzg.OpeningProof[FR, G1El]
@@ -1069,7 +1089,7 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertSameProofs(vk VerifyingKey[FR, G1
 		foldedProofs = append(foldedProofs, pr...)
 		foldedPoints = append(foldedPoints, pts...)
 	}
-	if err := v.kzg.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, vk.Kzg); err != nil {
+	if err := kzgVerifier.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, vk.Kzg); err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
 	return nil
@@ -1088,6 +1108,14 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertDifferentProofs(bvk BaseVerifying
 	if len(proofs) == 0 {
 		return fmt.Errorf("no proofs to check")
 	}
+	cfg, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+	kzgVerifier, err := v.kzgVerifier(cfg)
+	if err != nil {
+		return err
+	}
 	var foldedDigests []kzg.Commitment[G1El]
 	var foldedProofs []kzg.OpeningProof[FR, G1El]
 	var foldedPoints []emulated.Element[FR]
@@ -1104,12 +1132,26 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertDifferentProofs(bvk BaseVerifying
 		foldedProofs = append(foldedProofs, pr...)
 		foldedPoints = append(foldedPoints, pts...)
 	}
-	if err := v.kzg.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, bvk.Kzg); err != nil {
+	if err := kzgVerifier.BatchVerifyMultiPoints(foldedDigests, foldedProofs, foldedPoints, bvk.Kzg); err != nil {
 		return fmt.Errorf("batch verify kzg: %w", err)
 	}
 	return nil
 }
 
+// kzgVerifier returns the KZG verifier using the hash function defined in the
+// options. We reuse the verifier initialized in the constructor when the
+// default hash function is used.
+func (v *Verifier[FR, G1El, G2El, GtEl]) kzgVerifier(cfg *verifierCfg) (*kzg.Verifier[FR, G1El, G2El, GtEl], error) {
+	if len(cfg.kzgOpts) == 0 {
+		return v.kzg, nil
+	}
+	k, err := kzg.NewVerifier[FR, G1El, G2El, GtEl](v.api, cfg.kzgOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("new kzg verifier: %w", err)
+	}
+	return k, nil
+}
+
 func (v *Verifier[FR, G1El, G2El, GtEl]) bindPublicData(fs *fiatshamir.Transcript, challenge string, vk VerifyingKey[FR, G1El, G2El], witness Witness[FR]) error {
 
 	// permutation
@@ -187,7 +187,7 @@ func (c *InnerCircuitCommit) Define(api frontend.API) error {
 	return nil
 }
 
-func getInnerCommit(assert *test.Assert, field, outer *big.Int) (constraint.ConstraintSystem, native_plonk.VerifyingKey, witness.Witness, native_plonk.Proof) {
+func getInnerCommit(assert *test.Assert, field, outer *big.Int, opts ...recursion.HashOption) (constraint.ConstraintSystem, native_plonk.VerifyingKey, witness.Witness, native_plonk.Proof) {
 
 	innerCcs, err := frontend.Compile(field, scs.NewBuilder, &InnerCircuitCommit{})
 	assert.NoError(err)
@@ -206,12 +206,12 @@ func getInnerCommit(assert *test.Assert, field, outer *big.Int) (constraint.Cons
 	}
 	innerWitness, err := frontend.NewWitness(innerAssignment, field)
 	assert.NoError(err)
-	innerProof, err := native_plonk.Prove(innerCcs, innerPK, innerWitness, GetNativeProverOptions(outer, field))
+	innerProof, err := native_plonk.Prove(innerCcs, innerPK, innerWitness, GetNativeProverOptions(outer, field, opts...))
 
 	assert.NoError(err)
 	innerPubWitness, err := innerWitness.Public()
 	assert.NoError(err)
-	err = native_plonk.Verify(innerProof, innerVK, innerPubWitness, GetNativeVerifierOptions(outer, field))
+	err = native_plonk.Verify(innerProof, innerVK, innerPubWitness, GetNativeVerifierOptions(outer, field, opts...))
 
 	assert.NoError(err)
 	return innerCcs, innerVK, innerPubWitness, innerProof
@@ -245,6 +245,54 @@ func TestBLS12InBW6Commit(t *testing.T) {
 
 }
 
+type OuterCircuitHashFunction[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Proof        Proof[FR, G1El, G2El]
+	VerifyingKey VerifyingKey[FR, G1El, G2El] `gnark:"-"`
+	InnerWitness Witness[FR]                  `gnark:",public"`
+
+	hashFunction recursion.HashFunction
+}
+
+func (c *OuterCircuitHashFunction[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	return verifier.AssertProof(c.VerifyingKey, c.Proof, c.InnerWitness, WithCompleteArithmetic(), WithHashFunction(c.hashFunction))
+}
+
+func TestBLS12InBW6CommitPoseidon2(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, innerWitness, innerProof := getInnerCommit(assert, ecc.BLS12_377.ScalarField(), ecc.BW6_761.ScalarField(), recursion.WithHashFunction(recursion.Poseidon2))
+
+	circuitVk, err := ValueOfVerifyingKey[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfWitness[sw_bls12377.ScalarField](innerWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfProof[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterCircuitHashFunction[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+		InnerWitness: PlaceholderWitness[sw_bls12377.ScalarField](innerCcs),
+		Proof:        PlaceholderProof[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+		VerifyingKey: circuitVk,
+		hashFunction: recursion.Poseidon2,
+	}
+	outerAssignment := &OuterCircuitHashFunction[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+		hashFunction: recursion.Poseidon2,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+
+	// the proof computed with Poseidon2 challenges does not verify with MiMC.
+	outerCircuit.hashFunction = recursion.MiMC
+	outerAssignment.hashFunction = recursion.MiMC
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+}
+
 func TestBW6InBN254Commit(t *testing.T) {
 
 	assert := test.NewAssert(t)
@@ -9,9 +9,11 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	cryptomimc "github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
 	fiatshamir "github.com/consensys/gnark/std/fiat-shamir"
 	stdhash "github.com/consensys/gnark/std/hash"
 	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/hash/poseidon2"
 	"github.com/consensys/gnark/std/math/bits"
 	"golang.org/x/exp/slices"
 )
@@ -26,50 +28,121 @@ type shortNativeHash struct {
 	buf     []byte
 }
 
+// HashFunction identifies the SNARK-friendly hash function wrapped by the
+// hash functions returned by [NewShort] and [NewHash]. See the package
+// documentation for the supported combinations of fields and hash functions.
+type HashFunction int
+
+const (
+	// MiMC is the MiMC hash function over the native field. It is the default.
+	MiMC HashFunction = iota
+	// Poseidon2 is the Poseidon2 hash function over the native field with the
+	// default parameters. See [poseidon2.NewNativeHasher].
+	Poseidon2
+)
+
+// String returns the name of the hash function.
+func (hf HashFunction) String() string {
+	switch hf {
+	case MiMC:
+		return "MiMC"
+	case Poseidon2:
+		return "Poseidon2"
+	default:
+		return "unknown"
+	}
+}
+
+type hashConfig struct {
+	hf HashFunction
+}
+
+// HashOption allows to modify the behaviour of the hash functions returned by
+// [NewShort], [NewHash] and [NewTranscript].
+type HashOption func(cfg *hashConfig) error
+
+// WithHashFunction sets the underlying hash function. If not set, then [MiMC]
+// is used. The native and in-circuit hash functions must be initialized with
+// the same hash function for the outputs to match.
+func WithHashFunction(hf HashFunction) HashOption {
+	return func(cfg *hashConfig) error {
+		switch hf {
+		case MiMC, Poseidon2:
+		default:
+			return fmt.Errorf("unknown hash function %d", hf)
+		}
+		cfg.hf = hf
+		return nil
+	}
+}
+
+func newHashConfig(opts ...HashOption) (*hashConfig, error) {
+	cfg := new(hashConfig)
+	for i := range opts {
+		if err := opts[i](cfg); err != nil {
+			return nil, fmt.Errorf("option %d: %w", i, err)
+		}
+	}
+	return cfg, nil
+}
+
 // NewShort returns a native hash function which reads elements in the current native
 // field and outputs element in the target field (usually the scalar field of
-// the circuit being recursed). The hash function is based on MiMC and
-// partitions the excess bits to not overflow the target field.
-func NewShort(current, target *big.Int) (hash.Hash, error) {
+// the circuit being recursed). The hash function is based on MiMC by default
+// (see [WithHashFunction]) and partitions the excess bits to not overflow the
+// target field.
+func NewShort(current, target *big.Int, opts ...HashOption) (hash.Hash, error) {
+	cfg, err := newHashConfig(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("apply options: %w", err)
+	}
+	var hh hash.Hash
+	switch cfg.hf {
+	case MiMC:
+		if hh, err = newNativeMiMC(current); err != nil {
+			return nil, err
+		}
+	case Poseidon2:
+		curve := utils.FieldToCurve(current)
+		if curve == ecc.UNKNOWN {
+			return nil, fmt.Errorf("no default poseidon2 for scalar field: %s", current.String())
+		}
+		if hh, err = poseidon2.NewNativeHasher(curve); err != nil {
+			return nil, fmt.Errorf("get poseidon2: %w", err)
+		}
+	}
+	nbBits := target.BitLen()
+	if nbBits > current.BitLen() {
+		nbBits = current.BitLen()
+	}
+	return newShortFromParam(hh, current.BitLen(), nbBits), nil
+}
+
+func newNativeMiMC(current *big.Int) (hash.Hash, error) {
 	var h cryptomimc.Hash
-	var bitBlockSize int
 	switch current.String() {
 	case ecc.BN254.ScalarField().String():
 		h = cryptomimc.MIMC_BN254
-		bitBlockSize = ecc.BN254.ScalarField().BitLen()
 	case ecc.BLS12_381.ScalarField().String():
 		h = cryptomimc.MIMC_BLS12_381
-		bitBlockSize = ecc.BLS12_381.ScalarField().BitLen()
 	case ecc.BLS12_377.ScalarField().String():
 		h = cryptomimc.MIMC_BLS12_377
-		bitBlockSize = ecc.BLS12_377.ScalarField().BitLen()
 	case ecc.BLS12_378.ScalarField().String():
 		h = cryptomimc.MIMC_BLS12_378
-		bitBlockSize = ecc.BLS12_378.ScalarField().BitLen()
 	case ecc.BW6_761.ScalarField().String():
 		h = cryptomimc.MIMC_BW6_761
-		bitBlockSize = ecc.BW6_761.ScalarField().BitLen()
 	case ecc.BLS24_315.ScalarField().String():
 		h = cryptomimc.MIMC_BLS24_315
-		bitBlockSize = ecc.BLS24_315.ScalarField().BitLen()
 	case ecc.BLS24_317.ScalarField().String():
 		h = cryptomimc.MIMC_BLS24_317
-		bitBlockSize = ecc.BLS24_317.ScalarField().BitLen()
 	case ecc.BW6_633.ScalarField().String():
 		h = cryptomimc.MIMC_BW6_633
-		bitBlockSize = ecc.BW6_633.ScalarField().BitLen()
 	case ecc.BW6_756.ScalarField().String():
 		h = cryptomimc.MIMC_BW6_756
-		bitBlockSize = ecc.BW6_756.ScalarField().BitLen()
 	default:
 		return nil, fmt.Errorf("no default mimc for scalar field: %s", current.String())
 	}
-	hh := h.New()
-	nbBits := target.BitLen()
-	if nbBits > current.BitLen() {
-		nbBits = current.BitLen()
-	}
-	return newShortFromParam(hh, bitBlockSize, nbBits), nil
+	return h.New(), nil
 }
 
 func newShortFromParam(hf hash.Hash, bitBlockSize, outSize int) hash.Hash {
@@ -155,25 +228,41 @@ func newHashFromParameter(api frontend.API, hf stdhash.FieldHasher, bitLength in
 
 // NewHash returns a circuit hash function which reads elements in the current
 // native field and outputs element in the target field (usually the scalar
-// field of the circuit being recursed). The hash function is based on MiMC and
-// partitions the excess bits to not overflow the target field.
-func NewHash(api frontend.API, target *big.Int, bitmode bool) (stdhash.FieldHasher, error) {
-	h, err := mimc.NewMiMC(api)
+// field of the circuit being recursed). The hash function is based on MiMC by
+// default (see [WithHashFunction]) and partitions the excess bits to not
+// overflow the target field.
+func NewHash(api frontend.API, target *big.Int, bitmode bool, opts ...HashOption) (stdhash.FieldHasher, error) {
+	cfg, err := newHashConfig(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("get mimc: %w", err)
+		return nil, fmt.Errorf("apply options: %w", err)
+	}
+	var hf stdhash.FieldHasher
+	switch cfg.hf {
+	case MiMC:
+		h, err := mimc.NewMiMC(api)
+		if err != nil {
+			return nil, fmt.Errorf("get mimc: %w", err)
+		}
+		hf = &h
+	case Poseidon2:
+		h, err := poseidon2.NewPoseidon2(api)
+		if err != nil {
+			return nil, fmt.Errorf("get poseidon2: %w", err)
+		}
+		hf = &h
 	}
 	nbBits := target.BitLen()
 	if nbBits > api.Compiler().FieldBitLen() {
 		nbBits = api.Compiler().FieldBitLen()
 	}
-	return newHashFromParameter(api, &h, nbBits, bitmode), nil
+	return newHashFromParameter(api, hf, nbBits, bitmode), nil
 }
 
 // NewTranscript returns a new Fiat-Shamir transcript for computing bound
 // challenges. It uses hasher returned by [NewHash] internally and configures
 // the transcript to be compatible with gnark-crypto Fiat-Shamir transcript.
-func NewTranscript(api frontend.API, target *big.Int, challenges []string) (*fiatshamir.Transcript, error) {
-	h, err := NewHash(api, target, true)
+func NewTranscript(api frontend.API, target *big.Int, challenges []string, opts ...HashOption) (*fiatshamir.Transcript, error) {
+	h, err := NewHash(api, target, true, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("new hash: %w", err)
 	}
@@ -28,10 +28,11 @@ type shortHashCircuit struct {
 	Input  []frontend.Variable
 	Output frontend.Variable
 	inner  ecc.ID
+	hf     recursion.HashFunction
 }
 
 func (c *shortHashCircuit) Define(api frontend.API) error {
-	hasher, err := recursion.NewHash(api, c.inner.ScalarField(), false)
+	hasher, err := recursion.NewHash(api, c.inner.ScalarField(), false, recursion.WithHashFunction(c.hf))
 	if err != nil {
 		return err
 	}
@@ -55,33 +56,49 @@ func TestShortHash(t *testing.T) {
 		ecc.BW6_761,
 	}
 
+	hashFunctions := []recursion.HashFunction{
+		recursion.MiMC,
+		recursion.Poseidon2,
+	}
+
 	assert := test.NewAssert(t)
 	nbInputs := 19
 	for _, outer := range outerCurves {
 		outer := outer
 		for _, inner := range innerCurves {
 			inner := inner
-			assert.Run(func(assert *test.Assert) {
-				circuit := &shortHashCircuit{Input: make([]frontend.Variable, nbInputs), inner: inner}
-				h, err := recursion.NewShort(outer.ScalarField(), inner.ScalarField())
-				assert.NoError(err)
-				witness := &shortHashCircuit{Input: make([]frontend.Variable, nbInputs), inner: inner}
-				buf := make([]byte, (outer.ScalarField().BitLen()+7)/8)
-				for i := range witness.Input {
-					el, err := rand.Int(rand.Reader, outer.ScalarField())
+			for _, hf := range hashFunctions {
+				hf := hf
+				assert.Run(func(assert *test.Assert) {
+					circuit := &shortHashCircuit{Input: make([]frontend.Variable, nbInputs), inner: inner, hf: hf}
+					h, err := recursion.NewShort(outer.ScalarField(), inner.ScalarField(), recursion.WithHashFunction(hf))
 					assert.NoError(err)
-					el.FillBytes(buf)
-					h.Write(buf)
-					witness.Input[i] = el
-				}
-				res := h.Sum(nil)
-				witness.Output = res
-				assert.CheckCircuit(circuit, test.WithCurves(outer), test.WithValidAssignment(witness), test.NoFuzzing(), test.NoSerializationChecks(), test.NoSolidityChecks(), test.NoProverChecks())
-			}, outer.String(), inner.String())
+					witness := &shortHashCircuit{Input: make([]frontend.Variable, nbInputs), inner: inner, hf: hf}
+					buf := make([]byte, (outer.ScalarField().BitLen()+7)/8)
+					for i := range witness.Input {
+						el, err := rand.Int(rand.Reader, outer.ScalarField())
+						assert.NoError(err)
+						el.FillBytes(buf)
+						h.Write(buf)
+						witness.Input[i] = el
+					}
+					res := h.Sum(nil)
+					witness.Output = res
+					assert.CheckCircuit(circuit, test.WithCurves(outer), test.WithValidAssignment(witness), test.NoFuzzing(), test.NoSerializationChecks(), test.NoSolidityChecks(), test.NoProverChecks())
+				}, outer.String(), inner.String(), hf.String())
+			}
 		}
 	}
 }
 
+func TestShortHashUnsupported(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := recursion.NewShort(ecc.BLS24_315.ScalarField(), ecc.BN254.ScalarField(), recursion.WithHashFunction(recursion.Poseidon2))
+	assert.Error(err)
+	_, err = recursion.NewShort(ecc.BN254.ScalarField(), ecc.BN254.ScalarField(), recursion.WithHashFunction(recursion.HashFunction(42)))
+	assert.Error(err)
+}
+
 type hashMarshalG1Circuit[FR emulated.FieldParams, G1El algebra.G1ElementT] struct {
 	Point    G1El
 	Expected frontend.Variable
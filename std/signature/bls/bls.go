@@ -0,0 +1,158 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Verifier verifies BLS signatures over the pairing defined by the type
+// parameters.
+type Verifier[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	pairing algebra.Pairing[G1El, G2El, GtEl]
+	curve   curve[G1El, G2El]
+}
+
+// NewVerifier returns a new [Verifier] instance. It returns an error if the
+// type parametrisation is not supported.
+func NewVerifier[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](api frontend.API) (*Verifier[G1El, G2El, GtEl], error) {
+	pairing, err := algebra.GetPairing[G1El, G2El, GtEl](api)
+	if err != nil {
+		return nil, fmt.Errorf("get pairing: %w", err)
+	}
+	curve, err := getCurve[G1El, G2El](api)
+	if err != nil {
+		return nil, fmt.Errorf("get curve: %w", err)
+	}
+	return &Verifier[G1El, G2El, GtEl]{
+		pairing: pairing,
+		curve:   curve,
+	}, nil
+}
+
+// VerifyMinPk asserts that sig is a valid min-pk signature of the message msg
+// with the domain separation tag dst for the public key pk:
+//
+//	e(pk, H(msg)) == e(g₁, sig)
+func (v *Verifier[G1El, G2El, GtEl]) VerifyMinPk(pk *G1El, msg []uints.U8, dst []byte, sig *G2El) error {
+	return v.AggregateVerifyMinPk([]*G1El{pk}, [][]uints.U8{msg}, dst, sig)
+}
+
+// AggregateVerifyMinPk asserts that sig is a valid min-pk aggregate signature
+// of the messages msgs with the domain separation tag dst for the public keys
+// pks, where msgs[i] is signed by pks[i]:
+//
+//	∏ e(pks[i], H(msgs[i])) == e(g₁, sig)
+//
+// It returns an error if the lengths of the inputs mismatch or are zero.
+func (v *Verifier[G1El, G2El, GtEl]) AggregateVerifyMinPk(pks []*G1El, msgs [][]uints.U8, dst []byte, sig *G2El) error {
+	if len(pks) != len(msgs) {
+		return fmt.Errorf("mismatching number of public keys %d and messages %d", len(pks), len(msgs))
+	}
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	v.pairing.AssertIsOnG2(sig)
+	P := make([]*G1El, 0, len(pks)+1)
+	Q := make([]*G2El, 0, len(pks)+1)
+	P = append(P, v.curve.negG1(v.curve.g1Generator()))
+	// the pairing may store precomputed lines in the G2 inputs, so we pass
+	// copies to not modify the inputs.
+	sigCopy := *sig
+	Q = append(Q, &sigCopy)
+	for i := range pks {
+		h, err := v.curve.hashToG2(msgs[i], dst)
+		if err != nil {
+			return fmt.Errorf("hash to G2: %w", err)
+		}
+		P = append(P, pks[i])
+		Q = append(Q, h)
+	}
+	return v.pairing.PairingCheck(P, Q)
+}
+
+// FastAggregateVerifyMinPk asserts that sig is a valid min-pk aggregate
+// signature of the message msg with the domain separation tag dst for the
+// public keys pks. It aggregates the public keys and verifies the signature
+// for the aggregated key. It returns an error if no public keys are given.
+//
+// As in the proof of possession scheme of [BLS signatures], the caller must
+// have verified a proof of possession for every public key. Otherwise a rogue
+// key chosen from the other keys allows to forge aggregate signatures.
+//
+// [BLS signatures]: https://datatracker.ietf.org/doc/draft-irtf-cfrg-bls-signature/
+func (v *Verifier[G1El, G2El, GtEl]) FastAggregateVerifyMinPk(pks []*G1El, msg []uints.U8, dst []byte, sig *G2El) error {
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	apk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		apk = v.curve.addG1(apk, pks[i])
+	}
+	return v.VerifyMinPk(apk, msg, dst, sig)
+}
+
+// VerifyMinSig asserts that sig is a valid min-sig signature of the message
+// msg with the domain separation tag dst for the public key pk:
+//
+//	e(H(msg), pk) == e(sig, g₂)
+func (v *Verifier[G1El, G2El, GtEl]) VerifyMinSig(pk *G2El, msg []uints.U8, dst []byte, sig *G1El) error {
+	return v.AggregateVerifyMinSig([]*G2El{pk}, [][]uints.U8{msg}, dst, sig)
+}
+
+// AggregateVerifyMinSig asserts that sig is a valid min-sig aggregate
+// signature of the messages msgs with the domain separation tag dst for the
+// public keys pks, where msgs[i] is signed by pks[i]:
+//
+//	∏ e(H(msgs[i]), pks[i]) == e(sig, g₂)
+//
+// It returns an error if the lengths of the inputs mismatch or are zero.
+func (v *Verifier[G1El, G2El, GtEl]) AggregateVerifyMinSig(pks []*G2El, msgs [][]uints.U8, dst []byte, sig *G1El) error {
+	if len(pks) != len(msgs) {
+		return fmt.Errorf("mismatching number of public keys %d and messages %d", len(pks), len(msgs))
+	}
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	v.pairing.AssertIsOnG1(sig)
+	P := make([]*G1El, 0, len(pks)+1)
+	Q := make([]*G2El, 0, len(pks)+1)
+	P = append(P, sig)
+	Q = append(Q, v.curve.g2Generator())
+	// the pairing may store precomputed lines in the G2 inputs, so we pass
+	// copies to not modify the inputs.
+	for i := range pks {
+		h, err := v.curve.hashToG1(msgs[i], dst)
+		if err != nil {
+			return fmt.Errorf("hash to G1: %w", err)
+		}
+		pk := *pks[i]
+		P = append(P, v.curve.negG1(h))
+		Q = append(Q, &pk)
+	}
+	return v.pairing.PairingCheck(P, Q)
+}
+
+// FastAggregateVerifyMinSig asserts that sig is a valid min-sig aggregate
+// signature of the message msg with the domain separation tag dst for the
+// public keys pks. It aggregates the public keys and verifies the signature
+// for the aggregated key. It returns an error if no public keys are given.
+//
+// As in the proof of possession scheme of [BLS signatures], the caller must
+// have verified a proof of possession for every public key. Otherwise a rogue
+// key chosen from the other keys allows to forge aggregate signatures.
+//
+// [BLS signatures]: https://datatracker.ietf.org/doc/draft-irtf-cfrg-bls-signature/
+func (v *Verifier[G1El, G2El, GtEl]) FastAggregateVerifyMinSig(pks []*G2El, msg []uints.U8, dst []byte, sig *G1El) error {
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	apk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		apk = v.curve.addG2(apk, pks[i])
+	}
+	return v.VerifyMinSig(apk, msg, dst, sig)
+}
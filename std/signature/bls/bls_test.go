@@ -0,0 +1,267 @@
+package bls
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+const (
+	dstMinPk  = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	dstMinSig = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+	dst377    = "BLS_SIG_BLS12377G1_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+const (
+	modeVerify = iota
+	modeAggregate
+	modeFastAggregate
+)
+
+type minPkCircuit[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks  []G1El
+	Msgs [][]uints.U8
+	Sig  G2El
+	mode int
+}
+
+func (c *minPkCircuit[G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	pks := make([]*G1El, len(c.Pks))
+	for i := range pks {
+		pks[i] = &c.Pks[i]
+	}
+	switch c.mode {
+	case modeVerify:
+		return v.VerifyMinPk(pks[0], c.Msgs[0], []byte(dstMinPk), &c.Sig)
+	case modeAggregate:
+		return v.AggregateVerifyMinPk(pks, c.Msgs, []byte(dstMinPk), &c.Sig)
+	default:
+		return v.FastAggregateVerifyMinPk(pks, c.Msgs[0], []byte(dstMinPk), &c.Sig)
+	}
+}
+
+type minSigCircuit[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Pks  []G2El
+	Msgs [][]uints.U8
+	Sig  G1El
+	mode int
+	dst  string
+}
+
+func (c *minSigCircuit[G1El, G2El, GtEl]) Define(api frontend.API) error {
+	v, err := NewVerifier[G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	pks := make([]*G2El, len(c.Pks))
+	for i := range pks {
+		pks[i] = &c.Pks[i]
+	}
+	switch c.mode {
+	case modeVerify:
+		return v.VerifyMinSig(pks[0], c.Msgs[0], []byte(c.dst), &c.Sig)
+	case modeAggregate:
+		return v.AggregateVerifyMinSig(pks, c.Msgs, []byte(c.dst), &c.Sig)
+	default:
+		return v.FastAggregateVerifyMinSig(pks, c.Msgs[0], []byte(c.dst), &c.Sig)
+	}
+}
+
+// testMessages returns the messages for the test mode. All messages have the
+// same length for simpler circuit definitions.
+func testMessages(mode, nbKeys int) [][]byte {
+	if mode == modeFastAggregate {
+		return [][]byte{[]byte("message 0")}
+	}
+	msgs := make([][]byte, nbKeys)
+	for i := range msgs {
+		msgs[i] = []byte{'m', 'e', 's', 's', 'a', 'g', 'e', ' ', byte('0' + i)}
+	}
+	return msgs
+}
+
+func placeholderMessages(msgs [][]byte) [][]uints.U8 {
+	res := make([][]uints.U8, len(msgs))
+	for i := range msgs {
+		res[i] = make([]uints.U8, len(msgs[i]))
+	}
+	return res
+}
+
+func witnessMessages(msgs [][]byte) [][]uints.U8 {
+	res := make([][]uints.U8, len(msgs))
+	for i := range msgs {
+		res[i] = uints.NewU8Array(msgs[i])
+	}
+	return res
+}
+
+func TestMinPkBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, _ := bls12381.Generators()
+	for _, c := range []struct {
+		name   string
+		mode   int
+		nbKeys int
+	}{
+		{"verify", modeVerify, 1},
+		{"aggregate", modeAggregate, 2},
+		{"fast_aggregate", modeFastAggregate, 3},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			msgs := testMessages(c.mode, c.nbKeys)
+			pks := make([]sw_bls12381.G1Affine, c.nbKeys)
+			var sig bls12381.G2Affine
+			for i := range pks {
+				var sk fr_bls12381.Element
+				sk.SetRandom()
+				skb := sk.BigInt(new(big.Int))
+				var pk bls12381.G1Affine
+				pk.ScalarMultiplication(&g1, skb)
+				pks[i] = sw_bls12381.NewG1Affine(pk)
+				h, err := bls12381.HashToG2(msgs[min(i, len(msgs)-1)], []byte(dstMinPk))
+				assert.NoError(err)
+				h.ScalarMultiplication(&h, skb)
+				sig.Add(&sig, &h)
+			}
+			circuit := minPkCircuit[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]{
+				Pks:  make([]sw_bls12381.G1Affine, c.nbKeys),
+				Msgs: placeholderMessages(msgs),
+				mode: c.mode,
+			}
+			witness := minPkCircuit[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]{
+				Pks:  pks,
+				Msgs: witnessMessages(msgs),
+				Sig:  sw_bls12381.NewG2Affine(sig),
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+
+			if c.mode == modeVerify {
+				// the signature does not verify for a different message
+				witness.Msgs[0][0] = uints.NewU8('M')
+				err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.Error(err)
+			}
+		}, c.name)
+	}
+}
+
+func TestMinSigBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, _, g2 := bls12381.Generators()
+	for _, c := range []struct {
+		name   string
+		mode   int
+		nbKeys int
+	}{
+		{"verify", modeVerify, 1},
+		{"aggregate", modeAggregate, 2},
+		{"fast_aggregate", modeFastAggregate, 3},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			msgs := testMessages(c.mode, c.nbKeys)
+			pks := make([]sw_bls12381.G2Affine, c.nbKeys)
+			var sig bls12381.G1Affine
+			for i := range pks {
+				var sk fr_bls12381.Element
+				sk.SetRandom()
+				skb := sk.BigInt(new(big.Int))
+				var pk bls12381.G2Affine
+				pk.ScalarMultiplication(&g2, skb)
+				pks[i] = sw_bls12381.NewG2Affine(pk)
+				h, err := bls12381.HashToG1(msgs[min(i, len(msgs)-1)], []byte(dstMinSig))
+				assert.NoError(err)
+				h.ScalarMultiplication(&h, skb)
+				sig.Add(&sig, &h)
+			}
+			circuit := minSigCircuit[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]{
+				Pks:  make([]sw_bls12381.G2Affine, c.nbKeys),
+				Msgs: placeholderMessages(msgs),
+				mode: c.mode,
+				dst:  dstMinSig,
+			}
+			witness := minSigCircuit[sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl]{
+				Pks:  pks,
+				Msgs: witnessMessages(msgs),
+				Sig:  sw_bls12381.NewG1Affine(sig),
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+
+			if c.mode == modeVerify {
+				// the signature does not verify for a different message
+				witness.Msgs[0][0] = uints.NewU8('M')
+				err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+				assert.Error(err)
+			}
+		}, c.name)
+	}
+}
+
+func TestMinSigBLS12377(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, _, g2 := bls12377.Generators()
+	for _, c := range []struct {
+		name   string
+		mode   int
+		nbKeys int
+	}{
+		{"verify", modeVerify, 1},
+		{"aggregate", modeAggregate, 2},
+		{"fast_aggregate", modeFastAggregate, 3},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			msgs := testMessages(c.mode, c.nbKeys)
+			pks := make([]sw_bls12377.G2Affine, c.nbKeys)
+			var sig bls12377.G1Affine
+			for i := range pks {
+				var sk fr_bls12377.Element
+				sk.SetRandom()
+				skb := sk.BigInt(new(big.Int))
+				var pk bls12377.G2Affine
+				pk.ScalarMultiplication(&g2, skb)
+				pks[i] = sw_bls12377.NewG2Affine(pk)
+				h, err := bls12377.HashToG1(msgs[min(i, len(msgs)-1)], []byte(dst377))
+				assert.NoError(err)
+				h.ScalarMultiplication(&h, skb)
+				sig.Add(&sig, &h)
+			}
+			circuit := minSigCircuit[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+				Pks:  make([]sw_bls12377.G2Affine, c.nbKeys),
+				Msgs: placeholderMessages(msgs),
+				mode: c.mode,
+				dst:  dst377,
+			}
+			witness := minSigCircuit[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+				Pks:  pks,
+				Msgs: witnessMessages(msgs),
+				Sig:  sw_bls12377.NewG1Affine(sig),
+			}
+			err := test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+
+			if c.mode == modeVerify {
+				// the signature does not verify for a different message
+				witness.Msgs[0][0] = uints.NewU8('M')
+				err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+				assert.Error(err)
+			}
+		}, c.name)
+	}
+}
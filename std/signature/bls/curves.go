@@ -0,0 +1,149 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// curve defines the group operations which are not provided by
+// [algebra.Pairing] but are needed for verifying the signatures.
+type curve[G1El algebra.G1ElementT, G2El algebra.G2ElementT] interface {
+	// g1Generator returns the fixed generator of G1.
+	g1Generator() *G1El
+	// g2Generator returns the fixed generator of G2.
+	g2Generator() *G2El
+	// negG1 returns -p.
+	negG1(p *G1El) *G1El
+	// addG1 returns p+q using complete arithmetic.
+	addG1(p, q *G1El) *G1El
+	// addG2 returns p+q using complete arithmetic.
+	addG2(p, q *G2El) *G2El
+	// hashToG1 hashes msg to G1 with the domain separation tag dst.
+	hashToG1(msg []uints.U8, dst []byte) (*G1El, error)
+	// hashToG2 hashes msg to G2 with the domain separation tag dst.
+	hashToG2(msg []uints.U8, dst []byte) (*G2El, error)
+}
+
+// getCurve returns the [curve] implementation corresponding to the type
+// parameters.
+func getCurve[G1El algebra.G1ElementT, G2El algebra.G2ElementT](api frontend.API) (curve[G1El, G2El], error) {
+	var ret curve[G1El, G2El]
+	switch s := any(&ret).(type) {
+	case *curve[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
+		c, err := newBLS12381(api)
+		if err != nil {
+			return ret, err
+		}
+		*s = c
+	case *curve[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
+		*s = &curveBLS12377{api: api}
+	default:
+		return ret, fmt.Errorf("unknown type parametrisation")
+	}
+	return ret, nil
+}
+
+type curveBLS12381 struct {
+	curve *sw_emulated.Curve[sw_bls12381.BaseField, sw_bls12381.ScalarField]
+	g1    *sw_bls12381.G1
+	g2    *sw_bls12381.G2
+}
+
+func newBLS12381(api frontend.API) (*curveBLS12381, error) {
+	c, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	g1, err := sw_bls12381.NewG1(api)
+	if err != nil {
+		return nil, fmt.Errorf("new g1: %w", err)
+	}
+	return &curveBLS12381{
+		curve: c,
+		g1:    g1,
+		g2:    sw_bls12381.NewG2(api),
+	}, nil
+}
+
+func (c *curveBLS12381) g1Generator() *sw_bls12381.G1Affine {
+	_, _, g1, _ := bls12381.Generators()
+	res := sw_bls12381.NewG1Affine(g1)
+	return &res
+}
+
+func (c *curveBLS12381) g2Generator() *sw_bls12381.G2Affine {
+	_, _, _, g2 := bls12381.Generators()
+	res := sw_bls12381.NewG2AffineFixed(g2)
+	return &res
+}
+
+func (c *curveBLS12381) negG1(p *sw_bls12381.G1Affine) *sw_bls12381.G1Affine {
+	return c.curve.Neg(p)
+}
+
+func (c *curveBLS12381) addG1(p, q *sw_bls12381.G1Affine) *sw_bls12381.G1Affine {
+	return c.curve.AddUnified(p, q)
+}
+
+func (c *curveBLS12381) addG2(p, q *sw_bls12381.G2Affine) *sw_bls12381.G2Affine {
+	return c.g2.AddUnified(p, q)
+}
+
+func (c *curveBLS12381) hashToG1(msg []uints.U8, dst []byte) (*sw_bls12381.G1Affine, error) {
+	return c.g1.HashToG1(msg, dst)
+}
+
+func (c *curveBLS12381) hashToG2(msg []uints.U8, dst []byte) (*sw_bls12381.G2Affine, error) {
+	return c.g2.HashToG2(msg, dst)
+}
+
+type curveBLS12377 struct {
+	api frontend.API
+}
+
+func (c *curveBLS12377) g1Generator() *sw_bls12377.G1Affine {
+	_, _, g1, _ := bls12377.Generators()
+	res := sw_bls12377.NewG1Affine(g1)
+	return &res
+}
+
+func (c *curveBLS12377) g2Generator() *sw_bls12377.G2Affine {
+	_, _, _, g2 := bls12377.Generators()
+	res := sw_bls12377.NewG2AffineFixed(g2)
+	return &res
+}
+
+func (c *curveBLS12377) negG1(p *sw_bls12377.G1Affine) *sw_bls12377.G1Affine {
+	var res sw_bls12377.G1Affine
+	res.Neg(c.api, *p)
+	return &res
+}
+
+func (c *curveBLS12377) addG1(p, q *sw_bls12377.G1Affine) *sw_bls12377.G1Affine {
+	res := *p
+	res.AddUnified(c.api, *q)
+	return &res
+}
+
+func (c *curveBLS12377) addG2(p, q *sw_bls12377.G2Affine) *sw_bls12377.G2Affine {
+	res := p.P
+	res.AddUnified(c.api, q.P)
+	return &sw_bls12377.G2Affine{P: res}
+}
+
+func (c *curveBLS12377) hashToG1(msg []uints.U8, dst []byte) (*sw_bls12377.G1Affine, error) {
+	return sw_bls12377.HashToG1(c.api, msg, dst)
+}
+
+func (c *curveBLS12377) hashToG2(msg []uints.U8, dst []byte) (*sw_bls12377.G2Affine, error) {
+	return nil, errors.New("hash to G2 not supported for BLS12-377")
+}
@@ -0,0 +1,37 @@
+// Package bls implements BLS signature verification over pairing-friendly
+// elliptic curves.
+//
+// The package supports both variants of [BLS signatures]:
+//   - minimal-pubkey-size (min-pk), where the public keys are in G1 and the
+//     signatures and hashed messages are in G2;
+//   - minimal-signature-size (min-sig), where the public keys are in G2 and
+//     the signatures and hashed messages are in G1.
+//
+// For both variants, the package implements the verification of a single
+// signature, the verification of an aggregate signature on distinct messages
+// (AggregateVerify) and the verification of an aggregate signature on the same
+// message using public key aggregation (FastAggregateVerify). The messages are
+// hashed to the curve using the hash-to-curve gadgets of the [algebra]
+// packages with expand_message_xmd over SHA-256 as defined in [RFC 9380].
+//
+// The verifier is generic over the groups and is initialized with
+// [NewVerifier]. The following instantiations are supported:
+//   - BLS12-381 using non-native arithmetic, for both min-pk and min-sig;
+//   - BLS12-377 using native arithmetic over the scalar field of BW6-761, for
+//     min-sig only as hashing to G2 is not available.
+//
+// The signatures are asserted to be in the prime order subgroup. The public
+// keys are assumed to be validated (KeyValidate in [BLS signatures]) by the
+// caller, for example using the AssertIsOnG1 or AssertIsOnG2 methods of the
+// pairing if they are not trusted.
+//
+// FastAggregateVerify is only secure in the proof of possession scheme of [BLS
+// signatures]: every public key must come with a proof of possession verified
+// by the caller, for example when the key is registered. Without it, an
+// attacker choosing its public key from the keys of the other signers (rogue
+// key attack) can forge an aggregate signature on any message. The proofs of
+// possession are not verified by this package.
+//
+// [BLS signatures]: https://datatracker.ietf.org/doc/draft-irtf-cfrg-bls-signature/
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+package bls
@@ -0,0 +1,301 @@
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// nbChallengeBits is the bit length of the random coefficients in the linear
+// combination of the verification equations.
+const nbChallengeBits = 128
+
+// VerifyMany asserts that all the signatures sigs verify for the corresponding
+// messages msgs and public keys pks. The curve parameters params define the
+// elliptic curve.
+//
+// Instead of verifying every signature separately, we obtain the nonce points
+// Rᵢ from a hint and check the single equation
+//
+//	∑ [aᵢrᵢ/sᵢ]Qᵢ + [aᵢ](-Rᵢ) = [-∑ aᵢeᵢ/sᵢ]G
+//
+// for 128-bit coefficients aᵢ derived from the MiMC hash of all the inputs and
+// the nonce points. For every nonce point we only check that it is on the curve
+// and that its x-coordinate reduces to rᵢ modulo the group order, which is
+// considerably cheaper than recovering it from rᵢ. The left-hand side is
+// computed with interleaved double-and-add over all the signatures, so the
+// doublings are shared and every signature costs a single point addition per
+// bit of the scalars. If the curve has an efficient endomorphism, then the
+// scalars are additionally halved in length with the GLV decomposition. The
+// two fixed-base scalar multiplications are shared between all the
+// signatures, so the savings compared to individual verification grow with the
+// number of signatures. The native field must be supported by [mimc.NewMiMC].
+//
+// We assume that the messages msgs are already hashed to the scalar field. The
+// method panics if the lengths of the inputs mismatch.
+func VerifyMany[T, S emulated.FieldParams](api frontend.API, params sw_emulated.CurveParams, pks []PublicKey[T, S], msgs []emulated.Element[S], sigs []Signature[S]) {
+	if len(pks) != len(msgs) || len(pks) != len(sigs) {
+		panic(fmt.Sprintf("mismatching number of public keys %d, messages %d and signatures %d", len(pks), len(msgs), len(sigs)))
+	}
+	if len(pks) == 0 {
+		return
+	}
+	vf, err := newVerifier[T, S](api, params)
+	if err != nil {
+		panic(err)
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		panic(fmt.Errorf("new mimc: %w", err))
+	}
+	var fp T
+	var fr S
+	Qs := make([]*sw_emulated.AffinePoint[T], len(pks))
+	negRs := make([]*sw_emulated.AffinePoint[T], len(pks))
+	for i := range pks {
+		vf.assertScalars(&sigs[i])
+		Q := &sw_emulated.AffinePoint[T]{X: *vf.fp.Reduce(&pks[i].X), Y: *vf.fp.Reduce(&pks[i].Y)}
+		msg := vf.fr.Reduce(&msgs[i])
+		pk := PublicKey[T, S](*Q)
+		rs, err := api.Compiler().NewHint(nonceHint, 2*int(fp.NbLimbs()), nonceHintArgs(&params, &pk, msg, &sigs[i])...)
+		if err != nil {
+			panic(fmt.Errorf("new hint: %w", err))
+		}
+		R := &sw_emulated.AffinePoint[T]{
+			X: emulated.Element[T]{Limbs: rs[:fp.NbLimbs()]},
+			Y: emulated.Element[T]{Limbs: rs[fp.NbLimbs():]},
+		}
+		// R is on the curve and x(R) is canonical and reduces to r modulo the
+		// group order.
+		vf.curve.AssertIsOnCurve(R)
+		vf.fp.AssertIsInRange(&R.X)
+		vf.fr.AssertIsEqual(vf.baseToScalar(&R.X), &sigs[i].R)
+		Qs[i], negRs[i] = Q, vf.curve.Neg(R)
+		h.Write(vf.packLimbs(fp.BitsPerLimb(), Q.X.Limbs, Q.Y.Limbs, R.X.Limbs, R.Y.Limbs)...)
+		h.Write(vf.packLimbs(fr.BitsPerLimb(), msg.Limbs, sigs[i].R.Limbs, sigs[i].S.Limbs)...)
+	}
+
+	// the coefficients are derived from the digest of all the inputs. As they
+	// are computed after all the inputs are fixed, the prover cannot choose the
+	// inputs to cancel out in the linear combination.
+	seed := h.Sum()
+	g := vf.fr.Zero()
+	terms := make([]msmTerm[T], len(pks))
+	glv := params.Eigenvalue != nil && params.ThirdRootOne != nil
+	nbBits := int(fr.NbLimbs() * fr.BitsPerLimb())
+	if glv {
+		nbBits = fr.Modulus().BitLen()/2 + 2
+	}
+	for i := range pks {
+		a, aBits := vf.challenge(&h, seed, i)
+		t := vf.fr.Div(a, &sigs[i].S)
+		g = vf.fr.Add(g, vf.fr.Mul(t, &msgs[i]))
+		u := vf.fr.Reduce(vf.fr.Mul(t, &sigs[i].R))
+		if glv {
+			// [u]Q = [k₁]Q₁ + [k₂]Q₂ with Q₁ = ±Q and Q₂ = ±Φ(Q)
+			k1, k2, Q1, Q2 := vf.decompose(u, Qs[i])
+			terms[i] = msmTerm[T]{
+				points: []*sw_emulated.AffinePoint[T]{Q1, Q2, negRs[i]},
+				bits:   [][]frontend.Variable{vf.scalarBits(k1, nbBits), vf.scalarBits(k2, nbBits), padBits(aBits, nbBits)},
+			}
+		} else {
+			terms[i] = msmTerm[T]{
+				points: []*sw_emulated.AffinePoint[T]{Qs[i], negRs[i]},
+				bits:   [][]frontend.Variable{vf.fr.ToBits(u), padBits(aBits, nbBits)},
+			}
+		}
+	}
+
+	// the accumulator is initialised with the point O = [c]G for a random c,
+	// which the prover cannot predict. This ensures that the incomplete
+	// additions do not hit exceptional cases. We obtain [2ⁿ⁻¹]O in the result
+	// which we account for on the right-hand side.
+	c, _ := vf.challenge(&h, seed, len(pks))
+	O := vf.curve.ScalarMulBase(c)
+	lhs := vf.multiScalarMul(O, terms, nbBits)
+	offset := vf.fr.Mul(c, vf.fr.NewElement(new(big.Int).Lsh(big.NewInt(1), uint(nbBits-1))))
+	rhs := vf.curve.ScalarMulBase(vf.fr.Sub(offset, g))
+	vf.curve.AssertIsEqual(lhs, rhs)
+}
+
+// msmTerm is a set of points and the little-endian bits of the corresponding
+// scalars which are handled together in [verifier.multiScalarMul].
+type msmTerm[T emulated.FieldParams] struct {
+	points []*sw_emulated.AffinePoint[T]
+	bits   [][]frontend.Variable
+	// table contains the sums P₀ ± P₁ ± … ± Pₖ₋₁ where the sign of Pᵢ is
+	// negative if the (i-1)-th bit of the index is set.
+	table []*sw_emulated.AffinePoint[T]
+}
+
+// multiScalarMul returns [2ⁿ⁻¹]O + ∑ [sᵢ]Pᵢ for all the points and scalars in
+// the terms, where n is the number of bits of the scalars.
+//
+// We use the signed binary representation of the scalars, so that at every
+// bit we add ±P₀ ± … ± Pₖ₋₁ for every term. The sums are precomputed, the
+// doublings are shared between all the terms and the first addition is merged
+// with the doubling. The additions are incomplete, so we require that the sums
+// in the precomputed tables are not exceptional and that O is random.
+func (vf *verifier[T, S]) multiScalarMul(O *sw_emulated.AffinePoint[T], terms []msmTerm[T], nbBits int) *sw_emulated.AffinePoint[T] {
+	acc := O
+	for j := range terms {
+		terms[j].table = []*sw_emulated.AffinePoint[T]{terms[j].points[0]}
+		for _, P := range terms[j].points[1:] {
+			negP := vf.curve.Neg(P)
+			table := make([]*sw_emulated.AffinePoint[T], 2*len(terms[j].table))
+			for l, e := range terms[j].table {
+				vf.assertDifferentX(e, P)
+				table[l] = vf.curve.Add(e, P)
+				table[l+len(terms[j].table)] = vf.curve.Add(e, negP)
+			}
+			terms[j].table = table
+		}
+		// we assume that the most significant bits are set
+		acc = vf.curve.Add(acc, terms[j].table[0])
+	}
+	for i := nbBits - 1; i > 0; i-- {
+		for j := range terms {
+			B := vf.lookup(&terms[j], i)
+			if j == 0 {
+				// [2]acc + B = (acc + B) + acc
+				acc = vf.curve.Add(vf.curve.Add(acc, B), acc)
+			} else {
+				acc = vf.curve.Add(acc, B)
+			}
+		}
+	}
+	// the signed representation of s corresponds to s + 1 - s₀, subtract the
+	// points with even scalars.
+	for j := range terms {
+		for l, P := range terms[j].points {
+			acc = vf.curve.Select(terms[j].bits[l][0], acc, vf.curve.Add(acc, vf.curve.Neg(P)))
+		}
+	}
+	return acc
+}
+
+// lookup returns d₀P₀ + … + dₖ₋₁Pₖ₋₁ where dₗ = 2bₗ-1 for the i-th bits bₗ
+// of the scalars in the term.
+func (vf *verifier[T, S]) lookup(term *msmTerm[T], i int) *sw_emulated.AffinePoint[T] {
+	// when d₀ = -1, we negate the table entry with the opposite signs of the
+	// other points.
+	b0 := term.bits[0][i]
+	var idx frontend.Variable = 0
+	for l := 1; l < len(term.bits); l++ {
+		idx = vf.api.Add(idx, vf.api.Mul(vf.api.Xor(b0, term.bits[l][i]), 1<<(l-1)))
+	}
+	var B *sw_emulated.AffinePoint[T]
+	if len(term.table) == 2 {
+		B = vf.curve.Select(idx, term.table[1], term.table[0])
+	} else {
+		B = vf.curve.Mux(idx, term.table...)
+	}
+	return &sw_emulated.AffinePoint[T]{
+		X: B.X,
+		Y: *vf.fp.Select(b0, &B.Y, vf.fp.Neg(&B.Y)),
+	}
+}
+
+// decompose returns the GLV decomposition [u]Q = [k₁]Q₁ + [k₂]Q₂ where k₁ and
+// k₂ are short and Q₁ = ±Q and Q₂ = ±Φ(Q).
+func (vf *verifier[T, S]) decompose(u *emulated.Element[S], Q *sw_emulated.AffinePoint[T]) (k1, k2 *emulated.Element[S], Q1, Q2 *sw_emulated.AffinePoint[T]) {
+	eigenvalue := vf.fr.NewElement(vf.params.Eigenvalue)
+	sd, err := vf.fr.NewHint(decomposeScalarHint, 2, u, eigenvalue)
+	if err != nil {
+		panic(fmt.Errorf("compute GLV decomposition: %w", err))
+	}
+	signs, err := vf.fr.NewHintWithNativeOutput(decomposeScalarSignsHint, 2, u, eigenvalue)
+	if err != nil {
+		panic(fmt.Errorf("compute GLV decomposition signs: %w", err))
+	}
+	k1, k2 = sd[0], sd[1]
+	// u == ±k₁ + [λ](±k₂)
+	vf.fr.AssertIsEqual(
+		vf.fr.Add(
+			vf.fr.Select(signs[0], vf.fr.Neg(k1), k1),
+			vf.fr.Mul(vf.fr.Select(signs[1], vf.fr.Neg(k2), k2), eigenvalue),
+		),
+		u,
+	)
+	negQY := vf.fp.Neg(&Q.Y)
+	Q1 = &sw_emulated.AffinePoint[T]{
+		X: Q.X,
+		Y: *vf.fp.Select(signs[0], negQY, &Q.Y),
+	}
+	Q2 = &sw_emulated.AffinePoint[T]{
+		X: *vf.fp.Mul(&Q.X, vf.fp.NewElement(vf.params.ThirdRootOne)),
+		Y: *vf.fp.Select(signs[1], negQY, &Q.Y),
+	}
+	return k1, k2, Q1, Q2
+}
+
+// scalarBits returns the nbBits least significant bits of k and asserts that
+// the rest of the bits are zero.
+func (vf *verifier[T, S]) scalarBits(k *emulated.Element[S], nbBits int) []frontend.Variable {
+	bits := vf.fr.ToBits(k)
+	for _, b := range bits[nbBits:] {
+		vf.api.AssertIsEqual(b, 0)
+	}
+	return bits[:nbBits]
+}
+
+// challenge returns the scalar given by the 128 least significant bits of the
+// hash of seed and i, and the bits.
+func (vf *verifier[T, S]) challenge(h *mimc.MiMC, seed frontend.Variable, i int) (*emulated.Element[S], []frontend.Variable) {
+	var fr S
+	h.Reset()
+	h.Write(seed, i)
+	bits := vf.api.ToBinary(h.Sum())[:nbChallengeBits]
+	return vf.fr.FromBits(padBits(bits, int(fr.NbLimbs()*fr.BitsPerLimb()))...), bits
+}
+
+// assertDifferentX asserts that the x-coordinates of p and q are different.
+func (vf *verifier[T, S]) assertDifferentX(p, q *sw_emulated.AffinePoint[T]) {
+	vf.api.AssertIsEqual(vf.fp.IsZero(vf.fp.Sub(&p.X, &q.X)), 0)
+}
+
+// baseToScalar returns the integer value of the canonical base field element x
+// as a scalar field element.
+func (vf *verifier[T, S]) baseToScalar(x *emulated.Element[T]) *emulated.Element[S] {
+	var fp T
+	var fr S
+	if fp.BitsPerLimb() == fr.BitsPerLimb() && fp.NbLimbs() == fr.NbLimbs() {
+		// the limb decompositions coincide and the limbs are range checked
+		return &emulated.Element[S]{Limbs: x.Limbs}
+	}
+	return vf.fr.FromBits(vf.fp.ToBits(x)...)
+}
+
+// packLimbs packs the limbs of nbBits bits of all the elements into as few
+// native field elements as possible for hashing. It assumes that the limbs are
+// range checked.
+func (vf *verifier[T, S]) packLimbs(nbBits uint, elements ...[]frontend.Variable) []frontend.Variable {
+	var limbs []frontend.Variable
+	for _, e := range elements {
+		limbs = append(limbs, e...)
+	}
+	perVar := (vf.api.Compiler().FieldBitLen() - 1) / int(nbBits)
+	res := make([]frontend.Variable, 0, (len(limbs)+perVar-1)/perVar)
+	for i := 0; i < len(limbs); i += perVar {
+		acc := frontend.Variable(0)
+		for j := min(i+perVar, len(limbs)) - 1; j >= i; j-- {
+			acc = vf.api.Add(vf.api.Mul(acc, new(big.Int).Lsh(big.NewInt(1), nbBits)), limbs[j])
+		}
+		res = append(res, acc)
+	}
+	return res
+}
+
+// padBits pads the little-endian bits with zeros to the length n.
+func padBits(bits []frontend.Variable, n int) []frontend.Variable {
+	res := make([]frontend.Variable, n)
+	copy(res, bits)
+	for i := len(bits); i < n; i++ {
+		res[i] = 0
+	}
+	return res
+}
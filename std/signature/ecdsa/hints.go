@@ -0,0 +1,230 @@
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{nonceHint, decomposeScalarHint, decomposeScalarSignsHint}
+}
+
+// nonceHintArgs returns the inputs to [nonceHint]. The inputs are
+// the parameters of the base and scalar fields, the modulus, the curve
+// coefficient a and the generator of the curve, the public key, the message
+// and the signature. All elements are given as limbs.
+func nonceHintArgs[T, S emulated.FieldParams](params *sw_emulated.CurveParams, pk *PublicKey[T, S], msg *emulated.Element[S], sig *Signature[S]) []frontend.Variable {
+	var fp T
+	var fr S
+	args := []frontend.Variable{fp.BitsPerLimb(), fp.NbLimbs(), fr.BitsPerLimb(), fr.NbLimbs()}
+	for _, c := range []*big.Int{fp.Modulus(), params.A, params.Gx, params.Gy} {
+		args = append(args, constantLimbs(c, fp.BitsPerLimb(), fp.NbLimbs())...)
+	}
+	args = append(args, pk.X.Limbs...)
+	args = append(args, pk.Y.Limbs...)
+	args = append(args, constantLimbs(fr.Modulus(), fr.BitsPerLimb(), fr.NbLimbs())...)
+	args = append(args, msg.Limbs...)
+	args = append(args, sig.R.Limbs...)
+	args = append(args, sig.S.Limbs...)
+	return args
+}
+
+// nonceHint computes the nonce point R = [e/s]G + [r/s]Q of a valid signature
+// and returns its coordinates as limbs.
+func nonceHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 4 {
+		return fmt.Errorf("expected at least 4 inputs, got %d", len(inputs))
+	}
+	fpBits, fpLimbs := uint(inputs[0].Uint64()), int(inputs[1].Uint64())
+	frBits, frLimbs := uint(inputs[2].Uint64()), int(inputs[3].Uint64())
+	if len(inputs) != 4+6*fpLimbs+4*frLimbs {
+		return fmt.Errorf("expected %d inputs, got %d", 4+6*fpLimbs+4*frLimbs, len(inputs))
+	}
+	if len(outputs) != 2*fpLimbs {
+		return fmt.Errorf("expected %d outputs, got %d", 2*fpLimbs, len(outputs))
+	}
+	fpInputs := make([]*big.Int, 6)
+	for i := range fpInputs {
+		fpInputs[i] = recompose(inputs[4+i*fpLimbs:4+(i+1)*fpLimbs], fpBits)
+	}
+	offset := 4 + 6*fpLimbs
+	frInputs := make([]*big.Int, 4)
+	for i := range frInputs {
+		frInputs[i] = recompose(inputs[offset+i*frLimbs:offset+(i+1)*frLimbs], frBits)
+	}
+	// the elements may not be reduced
+	for i := 1; i < len(fpInputs); i++ {
+		fpInputs[i].Mod(fpInputs[i], fpInputs[0])
+	}
+	for i := 1; i < len(frInputs); i++ {
+		frInputs[i].Mod(frInputs[i], frInputs[0])
+	}
+	c := &affineCurve{p: fpInputs[0], a: fpInputs[1]}
+	G := [2]*big.Int{fpInputs[2], fpInputs[3]}
+	Q := [2]*big.Int{fpInputs[4], fpInputs[5]}
+	n, e, r, s := frInputs[0], frInputs[1], frInputs[2], frInputs[3]
+
+	sInv := new(big.Int).ModInverse(s, n)
+	if sInv == nil {
+		return fmt.Errorf("s is not invertible")
+	}
+	u1 := new(big.Int).Mul(e, sInv)
+	u1.Mod(u1, n)
+	u2 := new(big.Int).Mul(r, sInv)
+	u2.Mod(u2, n)
+	R := c.add(c.scalarMul(G, u1), c.scalarMul(Q, u2))
+	if R[0] == nil {
+		return fmt.Errorf("point at infinity")
+	}
+	if err := decompose(R[0], fpBits, outputs[:fpLimbs]); err != nil {
+		return fmt.Errorf("decompose x: %w", err)
+	}
+	if err := decompose(R[1], fpBits, outputs[fpLimbs:]); err != nil {
+		return fmt.Errorf("decompose y: %w", err)
+	}
+	return nil
+}
+
+// decomposeScalarHint returns the absolute values of the GLV decomposition
+// k₁ + λk₂ = k of the scalar k for the eigenvalue λ.
+func decomposeScalarHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	return emulated.UnwrapHint(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 {
+			return fmt.Errorf("expecting two inputs")
+		}
+		if len(outputs) != 2 {
+			return fmt.Errorf("expecting two outputs")
+		}
+		glvBasis := new(ecc.Lattice)
+		ecc.PrecomputeLattice(field, inputs[1], glvBasis)
+		sp := ecc.SplitScalar(inputs[0], glvBasis)
+		outputs[0].Abs(&sp[0])
+		outputs[1].Abs(&sp[1])
+		return nil
+	})
+}
+
+// decomposeScalarSignsHint returns 1 for the negative components of the GLV
+// decomposition computed in [decomposeScalarHint] and 0 otherwise.
+func decomposeScalarSignsHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	return emulated.UnwrapHintWithNativeOutput(inputs, outputs, func(field *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 {
+			return fmt.Errorf("expecting two inputs")
+		}
+		if len(outputs) != 2 {
+			return fmt.Errorf("expecting two outputs")
+		}
+		glvBasis := new(ecc.Lattice)
+		ecc.PrecomputeLattice(field, inputs[1], glvBasis)
+		sp := ecc.SplitScalar(inputs[0], glvBasis)
+		for i := range outputs {
+			outputs[i].SetUint64(0)
+			if sp[i].Sign() == -1 {
+				outputs[i].SetUint64(1)
+			}
+		}
+		return nil
+	})
+}
+
+// affineCurve implements the group law of the short Weierstrass curve
+// y² = x³ + ax + b over the prime field of order p. The point at infinity is
+// represented by nil coordinates.
+type affineCurve struct {
+	p, a *big.Int
+}
+
+func (c *affineCurve) add(P, Q [2]*big.Int) [2]*big.Int {
+	if P[0] == nil {
+		return Q
+	}
+	if Q[0] == nil {
+		return P
+	}
+	var lambda *big.Int
+	if P[0].Cmp(Q[0]) == 0 {
+		sum := new(big.Int).Add(P[1], Q[1])
+		if sum.Mod(sum, c.p).Sign() == 0 {
+			return [2]*big.Int{}
+		}
+		// λ = (3x² + a) / 2y
+		num := new(big.Int).Mul(P[0], P[0])
+		num.Mul(num, big.NewInt(3))
+		num.Add(num, c.a)
+		den := new(big.Int).Lsh(P[1], 1)
+		lambda = num.Mul(num, den.ModInverse(den, c.p))
+	} else {
+		// λ = (y₂ - y₁) / (x₂ - x₁)
+		num := new(big.Int).Sub(Q[1], P[1])
+		den := new(big.Int).Sub(Q[0], P[0])
+		den.Mod(den, c.p)
+		lambda = num.Mul(num, den.ModInverse(den, c.p))
+	}
+	lambda.Mod(lambda, c.p)
+	// x₃ = λ² - x₁ - x₂, y₃ = λ(x₁ - x₃) - y₁
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, P[0])
+	x.Sub(x, Q[0])
+	x.Mod(x, c.p)
+	y := new(big.Int).Sub(P[0], x)
+	y.Mul(y, lambda)
+	y.Sub(y, P[1])
+	y.Mod(y, c.p)
+	return [2]*big.Int{x, y}
+}
+
+func (c *affineCurve) scalarMul(P [2]*big.Int, s *big.Int) [2]*big.Int {
+	var res [2]*big.Int
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res = c.add(res, res)
+		if s.Bit(i) == 1 {
+			res = c.add(res, P)
+		}
+	}
+	return res
+}
+
+func recompose(inputs []*big.Int, nbBits uint) *big.Int {
+	res := new(big.Int)
+	for i := len(inputs) - 1; i >= 0; i-- {
+		res.Lsh(res, nbBits)
+		res.Add(res, inputs[i])
+	}
+	return res
+}
+
+func decompose(input *big.Int, nbBits uint, res []*big.Int) error {
+	if input.BitLen() > len(res)*int(nbBits) {
+		return fmt.Errorf("decomposed integer does not fit into res")
+	}
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), nbBits), big.NewInt(1))
+	tmp := new(big.Int).Set(input)
+	for i := range res {
+		res[i].And(tmp, mask)
+		tmp.Rsh(tmp, nbBits)
+	}
+	return nil
+}
+
+// constantLimbs decomposes the constant c into nbLimbs limbs of nbBits bits.
+func constantLimbs(c *big.Int, nbBits, nbLimbs uint) []frontend.Variable {
+	res := make([]frontend.Variable, nbLimbs)
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), nbBits), big.NewInt(1))
+	tmp := new(big.Int).Set(c)
+	for i := range res {
+		res[i] = new(big.Int).And(tmp, mask)
+		tmp.Rsh(tmp, nbBits)
+	}
+	return res
+}
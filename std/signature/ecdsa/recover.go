@@ -0,0 +1,116 @@
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// Recover recovers the public key from the signature sig over the message msg
+// and the recovery identifier v ∈ [0,3]. The lowest bit of v is the parity of
+// the y-coordinate of the nonce point R and the highest bit indicates that
+// the x-coordinate of R is r+n instead of r, where n is the order of the
+// curve. The curve parameters params define the elliptic curve.
+//
+// The circuit is not satisfiable if r or s is zero or not less than n, if
+// there is no point R corresponding to r and v, or if the recovered public key
+// is the point at infinity.
+//
+// We assume that the message msg is already hashed to the scalar field.
+func Recover[T, S emulated.FieldParams](api frontend.API, params sw_emulated.CurveParams, msg *emulated.Element[S], sig *Signature[S], v frontend.Variable) *PublicKey[T, S] {
+	vf, err := newVerifier[T, S](api, params)
+	if err != nil {
+		panic(err)
+	}
+	vf.assertScalars(sig)
+	R := vf.liftR(&sig.R, v)
+	// Q = [s/r]R + [-e/r]G
+	u1 := vf.fr.Div(vf.fr.Neg(msg), &sig.R)
+	u2 := vf.fr.Div(&sig.S, &sig.R)
+	Q := vf.curve.JointScalarMulBase(R, u2, u1, algopts.WithCompleteArithmetic())
+	api.AssertIsEqual(api.And(vf.fp.IsZero(&Q.X), vf.fp.IsZero(&Q.Y)), 0)
+	pk := PublicKey[T, S](*Q)
+	return &pk
+}
+
+type verifier[T, S emulated.FieldParams] struct {
+	api    frontend.API
+	params sw_emulated.CurveParams
+	fp     *emulated.Field[T]
+	fr     *emulated.Field[S]
+	curve  *sw_emulated.Curve[T, S]
+}
+
+func newVerifier[T, S emulated.FieldParams](api frontend.API, params sw_emulated.CurveParams) (*verifier[T, S], error) {
+	fp, err := emulated.NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base field: %w", err)
+	}
+	fr, err := emulated.NewField[S](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar field: %w", err)
+	}
+	curve, err := sw_emulated.New[T, S](api, params)
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	return &verifier[T, S]{
+		api:    api,
+		params: params,
+		fp:     fp,
+		fr:     fr,
+		curve:  curve,
+	}, nil
+}
+
+// assertScalars asserts that r and s of the signature are in the range [1,n).
+func (vf *verifier[T, S]) assertScalars(sig *Signature[S]) {
+	for _, x := range []*emulated.Element[S]{&sig.R, &sig.S} {
+		vf.fr.AssertIsInRange(x)
+		// the limbs are range checked, so their sum does not overflow and is
+		// zero only if all the limbs are zero.
+		var sum frontend.Variable = 0
+		for _, l := range x.Limbs {
+			sum = vf.api.Add(sum, l)
+		}
+		vf.api.AssertIsDifferent(sum, 0)
+	}
+}
+
+// liftR returns the point R with x-coordinate r + v₁·n and the parity of the
+// y-coordinate v₀. The circuit is not satisfiable if v is not in [0,3], if
+// r + v₁·n is not less than the modulus of the base field or if there is no
+// point with the given x-coordinate.
+func (vf *verifier[T, S]) liftR(r *emulated.Element[S], v frontend.Variable) *sw_emulated.AffinePoint[T] {
+	var fp T
+	var fr S
+	vBits := bits.ToBinary(vf.api, v, bits.WithNbDigits(2))
+	rr := vf.fr.Reduce(r)
+	vf.fr.AssertIsInRange(rr)
+	x := vf.fp.FromBits(vf.fr.ToBits(rr)...)
+	n := new(big.Int).Mod(fr.Modulus(), fp.Modulus())
+	x = vf.fp.Select(vBits[1], vf.fp.Add(x, vf.fp.NewElement(n)), x)
+	// the canonical x-coordinate reduced modulo n must equal r. This fails
+	// when r + v₁·n overflows the base field.
+	x = vf.fp.Reduce(x)
+	vf.fp.AssertIsInRange(x)
+	xBits := vf.fp.ToBits(x)
+	vf.fr.AssertIsEqual(vf.fr.FromBits(xBits...), rr)
+	// y² = x³ + ax + b
+	y2 := vf.fp.Mul(vf.fp.Mul(x, x), x)
+	if vf.params.A.Sign() != 0 {
+		y2 = vf.fp.Add(y2, vf.fp.Mul(vf.fp.NewElement(vf.params.A), x))
+	}
+	y2 = vf.fp.Add(y2, vf.fp.NewElement(vf.params.B))
+	y := vf.fp.Sqrt(y2)
+	yr := vf.fp.Reduce(y)
+	vf.fp.AssertIsInRange(yr)
+	parity := vf.fp.ToBits(yr)[0]
+	y = vf.fp.Select(vf.api.Xor(parity, vBits[0]), vf.fp.Neg(yr), yr)
+	return &sw_emulated.AffinePoint[T]{X: *x, Y: *y}
+}
@@ -0,0 +1,193 @@
+package ecdsa
+
+import (
+	cryptoecdsa "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type RecoverCircuit[T, S emulated.FieldParams] struct {
+	Msg      emulated.Element[S]
+	Sig      Signature[S]
+	V        frontend.Variable
+	Expected PublicKey[T, S]
+}
+
+func (c *RecoverCircuit[T, S]) Define(api frontend.API) error {
+	pk := Recover[T, S](api, sw_emulated.GetCurveParams[T](), &c.Msg, &c.Sig, c.V)
+	curve, err := sw_emulated.New[T, S](api, sw_emulated.GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	expected := sw_emulated.AffinePoint[T](c.Expected)
+	curve.AssertIsEqual((*sw_emulated.AffinePoint[T])(pk), &expected)
+	return nil
+}
+
+func TestRecoverSecp256k1(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := ecdsa.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing ECDSA recovery")
+	v, r, s, err := sk.SignForRecover(msg, nil)
+	assert.NoError(err)
+
+	circuit := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	witness := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Msg: emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg)),
+		Sig: Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		V: v,
+		Expected: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](sk.PublicKey.A.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](sk.PublicKey.A.Y),
+		},
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong parity of the nonce point recovers a different public key
+	witness.V = v ^ 1
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestRecoverP256(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := cryptoecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+	hash := sha256.Sum256([]byte("testing ECDSA recovery"))
+	r, s, err := cryptoecdsa.Sign(rand.Reader, sk, hash[:])
+	assert.NoError(err)
+	v := recoveryID(&sk.PublicKey, hash[:], r, s)
+
+	circuit := RecoverCircuit[emulated.P256Fp, emulated.P256Fr]{}
+	witness := RecoverCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Msg: emulated.ValueOf[emulated.P256Fr](hash[:]),
+		Sig: Signature[emulated.P256Fr]{
+			R: emulated.ValueOf[emulated.P256Fr](r),
+			S: emulated.ValueOf[emulated.P256Fr](s),
+		},
+		V: v,
+		Expected: PublicKey[emulated.P256Fp, emulated.P256Fr]{
+			X: emulated.ValueOf[emulated.P256Fp](sk.PublicKey.X),
+			Y: emulated.ValueOf[emulated.P256Fp](sk.PublicKey.Y),
+		},
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type VerifyManyCircuit[T, S emulated.FieldParams] struct {
+	Pubs []PublicKey[T, S]
+	Msgs []emulated.Element[S]
+	Sigs []Signature[S]
+}
+
+func (c *VerifyManyCircuit[T, S]) Define(api frontend.API) error {
+	VerifyMany(api, sw_emulated.GetCurveParams[T](), c.Pubs, c.Msgs, c.Sigs)
+	return nil
+}
+
+func TestVerifyManySecp256k1(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbSigs = 3
+	circuit := VerifyManyCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Pubs: make([]PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.Secp256k1Fr], nbSigs),
+		Sigs: make([]Signature[emulated.Secp256k1Fr], nbSigs),
+	}
+	witness := VerifyManyCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Pubs: make([]PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.Secp256k1Fr], nbSigs),
+		Sigs: make([]Signature[emulated.Secp256k1Fr], nbSigs),
+	}
+	for i := 0; i < nbSigs; i++ {
+		sk, err := ecdsa.GenerateKey(rand.Reader)
+		assert.NoError(err)
+		msg := []byte{byte(i)}
+		_, r, s, err := sk.SignForRecover(msg, nil)
+		assert.NoError(err)
+		witness.Pubs[i] = PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](sk.PublicKey.A.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](sk.PublicKey.A.Y),
+		}
+		witness.Msgs[i] = emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg))
+		witness.Sigs[i] = Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		}
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// swapping the messages invalidates the signatures
+	witness.Msgs[0], witness.Msgs[1] = witness.Msgs[1], witness.Msgs[0]
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestVerifyManyP256(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbSigs = 2
+	circuit := VerifyManyCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Pubs: make([]PublicKey[emulated.P256Fp, emulated.P256Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.P256Fr], nbSigs),
+		Sigs: make([]Signature[emulated.P256Fr], nbSigs),
+	}
+	witness := VerifyManyCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Pubs: make([]PublicKey[emulated.P256Fp, emulated.P256Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.P256Fr], nbSigs),
+		Sigs: make([]Signature[emulated.P256Fr], nbSigs),
+	}
+	for i := 0; i < nbSigs; i++ {
+		sk, err := cryptoecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(err)
+		hash := sha256.Sum256([]byte{byte(i)})
+		r, s, err := cryptoecdsa.Sign(rand.Reader, sk, hash[:])
+		assert.NoError(err)
+		witness.Pubs[i] = PublicKey[emulated.P256Fp, emulated.P256Fr]{
+			X: emulated.ValueOf[emulated.P256Fp](sk.PublicKey.X),
+			Y: emulated.ValueOf[emulated.P256Fp](sk.PublicKey.Y),
+		}
+		witness.Msgs[i] = emulated.ValueOf[emulated.P256Fr](hash[:])
+		witness.Sigs[i] = Signature[emulated.P256Fr]{
+			R: emulated.ValueOf[emulated.P256Fr](r),
+			S: emulated.ValueOf[emulated.P256Fr](s),
+		}
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+// recoveryID returns the recovery identifier of the P-256 signature (r, s) on
+// the message hash.
+func recoveryID(pk *cryptoecdsa.PublicKey, hash []byte, r, s *big.Int) uint {
+	curve := elliptic.P256()
+	n := curve.Params().N
+	sInv := new(big.Int).ModInverse(s, n)
+	u1 := new(big.Int).Mul(new(big.Int).SetBytes(hash), sInv)
+	u1.Mod(u1, n)
+	u2 := new(big.Int).Mul(r, sInv)
+	u2.Mod(u2, n)
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())
+	x2, y2 := curve.ScalarMult(pk.X, pk.Y, u2.Bytes())
+	x, y := curve.Add(x1, y1, x2, y2)
+	v := y.Bit(0)
+	if x.Cmp(n) >= 0 {
+		v |= 2
+	}
+	return v
+}
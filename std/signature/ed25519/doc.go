@@ -0,0 +1,19 @@
+// Package ed25519 implements Ed25519 signature verification as defined in RFC
+// 8032.
+//
+// The package depends on the [emulated/te_emulated] package for elliptic curve
+// group operations in twisted Edwards form using non-native arithmetic and on
+// the [hash/sha2] package for SHA-512. Unlike the [signature/eddsa] package,
+// the base field of the curve does not have to be the native field, so the
+// signatures can be verified in any SNARK field, for example BN254 or
+// BLS12-377.
+//
+// The public keys and signatures are given in their encoded form and decoded
+// in-circuit. The verification uses the cofactorless equation [S]B = R + [k]A,
+// which is compatible with the [crypto/ed25519] package in the Go standard
+// library.
+//
+// See [RFC 8032] for the signature verification algorithm.
+//
+// [RFC 8032]: https://www.rfc-editor.org/rfc/rfc8032
+package ed25519
@@ -0,0 +1,163 @@
+package ed25519
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/te_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Fp is the base field of Ed25519.
+type Fp = emulated.Ed25519Fp
+
+// Fr is the order of the prime order subgroup of Ed25519.
+type Fr = emulated.Ed25519Fr
+
+// PublicKey represents the 32-byte encoding of the public key A.
+type PublicKey struct {
+	A [32]uints.U8
+}
+
+// Signature represents the 64-byte signature (R, S) for some message, where R
+// is the encoding of the nonce point and S is the little-endian encoding of the
+// scalar.
+type Signature struct {
+	R [32]uints.U8
+	S [32]uints.U8
+}
+
+// ValueOfPublicKey returns the witness assignment of the encoded public key.
+// It panics if the length of the encoding is not 32 bytes.
+func ValueOfPublicKey(pk []byte) PublicKey {
+	if len(pk) != 32 {
+		panic(fmt.Sprintf("invalid public key length %d", len(pk)))
+	}
+	var res PublicKey
+	copy(res.A[:], uints.NewU8Array(pk))
+	return res
+}
+
+// ValueOfSignature returns the witness assignment of the encoded signature. It
+// panics if the length of the encoding is not 64 bytes.
+func ValueOfSignature(sig []byte) Signature {
+	if len(sig) != 64 {
+		panic(fmt.Sprintf("invalid signature length %d", len(sig)))
+	}
+	var res Signature
+	copy(res.R[:], uints.NewU8Array(sig[:32]))
+	copy(res.S[:], uints.NewU8Array(sig[32:]))
+	return res
+}
+
+// Verify asserts that the signature sig verifies for the message msg and the
+// public key pk as defined in RFC 8032. The circuit is not satisfiable if the
+// encodings of the public key or the nonce point are not canonical or do not
+// correspond to points on the curve, or if S is not less than the group order.
+func (pk PublicKey) Verify(api frontend.API, msg []uints.U8, sig *Signature) error {
+	v, err := newVerifier(api)
+	if err != nil {
+		return err
+	}
+	A := v.decodePoint(pk.A[:])
+	R := v.decodePoint(sig.R[:])
+	S := v.fr.FromBits(v.bytesToBits(sig.S[:])...)
+	v.fr.AssertIsInRange(S)
+
+	// k = SHA-512(R ‖ A ‖ M) mod L
+	h, err := sha2.New512(api)
+	if err != nil {
+		return fmt.Errorf("new sha512: %w", err)
+	}
+	h.Write(sig.R[:])
+	h.Write(pk.A[:])
+	h.Write(msg)
+	digest := h.Sum()
+	k := v.fr.Add(
+		v.fr.FromBits(v.bytesToBits(digest[:32])...),
+		v.fr.Mul(v.fr.FromBits(v.bytesToBits(digest[32:])...), v.fr.NewElement(v.shift)),
+	)
+
+	// [S]B - [k]A = R
+	Q := v.curve.JointScalarMulBase(v.curve.Neg(A), k, S)
+	v.curve.AssertIsEqual(Q, R)
+	return nil
+}
+
+type verifier struct {
+	api   frontend.API
+	fp    *emulated.Field[Fp]
+	fr    *emulated.Field[Fr]
+	curve *te_emulated.Curve[Fp, Fr]
+	// params are the parameters of Ed25519
+	params te_emulated.CurveParams
+	// shift is 2²⁵⁶ mod L for reducing the 512-bit hash digest
+	shift *big.Int
+}
+
+func newVerifier(api frontend.API) (*verifier, error) {
+	fp, err := emulated.NewField[Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base field: %w", err)
+	}
+	fr, err := emulated.NewField[Fr](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar field: %w", err)
+	}
+	params := te_emulated.GetCurveParams[Fp]()
+	curve, err := te_emulated.New[Fp, Fr](api, params)
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	shift := new(big.Int).Lsh(big.NewInt(1), 256)
+	shift.Mod(shift, Fr{}.Modulus())
+	return &verifier{
+		api:    api,
+		fp:     fp,
+		fr:     fr,
+		curve:  curve,
+		params: params,
+		shift:  shift,
+	}, nil
+}
+
+// decodePoint returns the point encoded in the 32 bytes buf as defined in RFC
+// 8032. The encoding is the little-endian y-coordinate with the most
+// significant bit set to the least significant bit of the x-coordinate. The
+// circuit is not satisfiable if y is not less than the modulus, if there is no
+// point with the y-coordinate or if x is zero and the sign bit is set.
+func (v *verifier) decodePoint(buf []uints.U8) *te_emulated.AffinePoint[Fp] {
+	bits := v.bytesToBits(buf)
+	sign := bits[255]
+	y := v.fp.FromBits(bits[:255]...)
+	v.fp.AssertIsInRange(y)
+	// x² = (y² - 1) / (dy² - a)
+	yy := v.fp.Mul(y, y)
+	u := v.fp.Sub(yy, v.fp.One())
+	w := v.fp.Sub(v.fp.Mul(v.fp.NewElement(v.params.D), yy), v.fp.NewElement(v.params.A))
+	x := v.fp.Sqrt(v.fp.Div(u, w))
+	x = v.fp.Reduce(x)
+	v.fp.AssertIsInRange(x)
+	// the limbs are range checked, so their sum does not overflow and is zero
+	// only if x is zero.
+	var sum frontend.Variable = 0
+	for _, l := range x.Limbs {
+		sum = v.api.Add(sum, l)
+	}
+	v.api.AssertIsEqual(v.api.And(v.api.IsZero(sum), sign), 0)
+	parity := v.fp.ToBits(x)[0]
+	x = v.fp.Select(v.api.Xor(parity, sign), v.fp.Neg(x), x)
+	return &te_emulated.AffinePoint[Fp]{X: *x, Y: *y}
+}
+
+// bytesToBits returns the little-endian bits of the little-endian bytes.
+func (v *verifier) bytesToBits(bytes []uints.U8) []frontend.Variable {
+	bits := make([]frontend.Variable, 0, 8*len(bytes))
+	for i := range bytes {
+		bits = append(bits, v.api.ToBinary(bytes[i].Val, 8)...)
+	}
+	return bits
+}
@@ -0,0 +1,82 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type verifyCircuit struct {
+	Pk  PublicKey
+	Msg []uints.U8
+	Sig Signature
+}
+
+func (c *verifyCircuit) Define(api frontend.API) error {
+	return c.Pk.Verify(api, c.Msg, &c.Sig)
+}
+
+func TestVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing Ed25519 signature verification")
+	sig := ed25519.Sign(sk, msg)
+
+	circuit := verifyCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := verifyCircuit{
+		Pk:  ValueOfPublicKey(pk),
+		Msg: uints.NewU8Array(msg),
+		Sig: ValueOfSignature(sig),
+	}
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377} {
+		err = test.IsSolved(&circuit, &witness, curve.ScalarField())
+		assert.NoError(err)
+	}
+
+	// modified message
+	msg[0] ^= 1
+	witness.Msg = uints.NewU8Array(msg)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestVerifyRFC8032(t *testing.T) {
+	assert := test.NewAssert(t)
+	// RFC 8032, Section 7.1, TEST 2
+	pk, _ := hex.DecodeString("3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c")
+	msg, _ := hex.DecodeString("72")
+	sig, _ := hex.DecodeString("92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00")
+
+	circuit := verifyCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := verifyCircuit{
+		Pk:  ValueOfPublicKey(pk),
+		Msg: uints.NewU8Array(msg),
+		Sig: ValueOfSignature(sig),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// S + L is not a canonical encoding of the scalar
+	s := new(big.Int).SetBytes(reverse(sig[32:]))
+	s.Add(s, Fr{}.Modulus())
+	copy(sig[32:], reverse(s.FillBytes(make([]byte, 32))))
+	witness.Sig = ValueOfSignature(sig)
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func reverse(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[len(b)-1-i] = b[i]
+	}
+	return res
+}
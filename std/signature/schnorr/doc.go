@@ -0,0 +1,17 @@
+// Package schnorr implements BIP-340 Schnorr signature verification over
+// secp256k1.
+//
+// The package depends on the [emulated/sw_emulated] package for elliptic curve
+// group operations using non-native arithmetic and on the [hash/sha2] package
+// for the tagged hashes. The public keys are given in x-only form and lifted to
+// the point with even y-coordinate in-circuit.
+//
+// Multiple signatures can be verified at once using [BatchVerify]. The batch
+// verification uses a random linear combination of the verification equations
+// where the coefficients are derived in-circuit from the hash of all the
+// signatures, public keys and messages.
+//
+// See [BIP-340] for the signature verification algorithm.
+//
+// [BIP-340]: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+package schnorr
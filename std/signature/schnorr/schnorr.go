@@ -0,0 +1,247 @@
+package schnorr
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Fp is the base field of secp256k1.
+type Fp = emulated.Secp256k1Fp
+
+// Fr is the scalar field of secp256k1.
+type Fr = emulated.Secp256k1Fr
+
+// PublicKey represents the x-only public key to verify the signature for.
+type PublicKey struct {
+	X emulated.Element[Fp]
+}
+
+// Signature represents the signature (r, s) for some message, where r is the
+// x-coordinate of the nonce point.
+type Signature struct {
+	R emulated.Element[Fp]
+	S emulated.Element[Fr]
+}
+
+const (
+	tagChallenge = "BIP0340/challenge"
+	tagBatch     = "BIP0340/batch"
+)
+
+// Verify asserts that the signature sig verifies for the message msg and the
+// public key pk as defined in BIP-340.
+func (pk PublicKey) Verify(api frontend.API, msg []uints.U8, sig *Signature) error {
+	v, err := newVerifier(api)
+	if err != nil {
+		return err
+	}
+	P, pxBytes := v.liftX(&pk.X)
+	rBytes := v.fpToBytes(&sig.R)
+	v.fr.AssertIsInRange(&sig.S)
+	e, _, err := v.challenge(rBytes, pxBytes, msg)
+	if err != nil {
+		return err
+	}
+
+	// R = [s]G - [e]P
+	R := v.curve.JointScalarMulBase(P, v.fr.Neg(e), &sig.S, algopts.WithCompleteArithmetic())
+	// R is not the point at infinity, has even y-coordinate and x(R) = r. The
+	// point at infinity is (0,0) and there is no point with y=0 on secp256k1.
+	v.api.AssertIsEqual(v.fp.IsZero(&R.Y), 0)
+	v.api.AssertIsEqual(v.parity(&R.Y), 0)
+	v.fp.AssertIsEqual(&R.X, &sig.R)
+	return nil
+}
+
+// BatchVerify asserts that all the signatures sigs verify for the
+// corresponding messages msgs and public keys pks. It checks the single
+// equation
+//
+//	[∑ aᵢsᵢ]G = ∑ [aᵢ]Rᵢ + [aᵢeᵢ]Pᵢ
+//
+// with a₁ = 1 and aᵢ = cⁱ⁻¹ for a 128-bit challenge c derived from the hash of
+// all the inputs. It returns an error if the lengths of the inputs mismatch or
+// are zero.
+func BatchVerify(api frontend.API, pks []PublicKey, msgs [][]uints.U8, sigs []Signature) error {
+	if len(pks) != len(msgs) || len(pks) != len(sigs) {
+		return fmt.Errorf("mismatching number of public keys %d, messages %d and signatures %d", len(pks), len(msgs), len(sigs))
+	}
+	if len(pks) == 0 {
+		return errors.New("no signatures")
+	}
+	v, err := newVerifier(api)
+	if err != nil {
+		return err
+	}
+	points := make([]*sw_emulated.AffinePoint[Fp], 0, 2*len(pks))
+	es := make([]*emulated.Element[Fr], len(pks))
+	batch := make([]uints.U8, 0, 128*len(pks))
+	for i := range pks {
+		P, pxBytes := v.liftX(&pks[i].X)
+		R, rBytes := v.liftX(&sigs[i].R)
+		sBytes := v.frToBytes(&sigs[i].S)
+		e, eBytes, err := v.challenge(rBytes, pxBytes, msgs[i])
+		if err != nil {
+			return err
+		}
+		es[i] = e
+		points = append(points, R, P)
+		batch = append(batch, rBytes...)
+		batch = append(batch, pxBytes...)
+		batch = append(batch, sBytes...)
+		batch = append(batch, eBytes...)
+	}
+
+	// the challenge c is the 128 least significant bits of the tagged hash of
+	// all the inputs. As it is computed after all the inputs are fixed, the
+	// prover cannot choose the inputs to cancel out in the linear combination.
+	digest, err := v.taggedHash(tagBatch, batch)
+	if err != nil {
+		return err
+	}
+	c := v.bytesToFr(digest[16:])
+
+	a := v.fr.One()
+	s := v.fr.Zero()
+	scalars := make([]*emulated.Element[Fr], 0, 2*len(pks))
+	for i := range pks {
+		if i > 0 {
+			a = v.fr.Mul(a, c)
+		}
+		s = v.fr.Add(s, v.fr.Mul(a, &sigs[i].S))
+		scalars = append(scalars, a, v.fr.Mul(a, es[i]))
+	}
+	lhs := v.curve.ScalarMulBase(s, algopts.WithCompleteArithmetic())
+	rhs, err := v.curve.MultiScalarMul(points, scalars, algopts.WithCompleteArithmetic())
+	if err != nil {
+		return fmt.Errorf("multi scalar mul: %w", err)
+	}
+	v.curve.AssertIsEqual(lhs, rhs)
+	return nil
+}
+
+type verifier struct {
+	api   frontend.API
+	fp    *emulated.Field[Fp]
+	fr    *emulated.Field[Fr]
+	curve *sw_emulated.Curve[Fp, Fr]
+	uapi  *uints.BinaryField[uints.U32]
+}
+
+func newVerifier(api frontend.API) (*verifier, error) {
+	fp, err := emulated.NewField[Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base field: %w", err)
+	}
+	fr, err := emulated.NewField[Fr](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar field: %w", err)
+	}
+	curve, err := sw_emulated.New[Fp, Fr](api, sw_emulated.GetSecp256k1Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints: %w", err)
+	}
+	return &verifier{
+		api:   api,
+		fp:    fp,
+		fr:    fr,
+		curve: curve,
+		uapi:  uapi,
+	}, nil
+}
+
+// liftX returns the point with x-coordinate x and even y-coordinate and the
+// 32-byte encoding of x. The circuit is not satisfiable if x is not the
+// x-coordinate of a point on the curve or x is not less than the modulus.
+func (v *verifier) liftX(x *emulated.Element[Fp]) (*sw_emulated.AffinePoint[Fp], []uints.U8) {
+	xBytes := v.fpToBytes(x)
+	// y² = x³ + 7
+	c := v.fp.Mul(v.fp.Mul(x, x), x)
+	c = v.fp.Add(c, v.fp.NewElement(7))
+	y := v.fp.Sqrt(c)
+	y = v.fp.Select(v.parity(y), v.fp.Neg(y), y)
+	return &sw_emulated.AffinePoint[Fp]{X: *x, Y: *y}, xBytes
+}
+
+// challenge returns e = int(hash_BIP0340/challenge(r ‖ x(P) ‖ m)) mod n and the
+// hash digest.
+func (v *verifier) challenge(rBytes, pxBytes, msg []uints.U8) (*emulated.Element[Fr], []uints.U8, error) {
+	in := make([]uints.U8, 0, len(rBytes)+len(pxBytes)+len(msg))
+	in = append(in, rBytes...)
+	in = append(in, pxBytes...)
+	in = append(in, msg...)
+	digest, err := v.taggedHash(tagChallenge, in)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v.fr.Reduce(v.bytesToFr(digest)), digest, nil
+}
+
+// taggedHash returns SHA256(SHA256(tag) ‖ SHA256(tag) ‖ msg).
+func (v *verifier) taggedHash(tag string, msg []uints.U8) ([]uints.U8, error) {
+	h, err := sha2.New(v.api)
+	if err != nil {
+		return nil, fmt.Errorf("new sha2: %w", err)
+	}
+	tagHash := sha256.Sum256([]byte(tag))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(msg)
+	return h.Sum(), nil
+}
+
+// parity returns the least significant bit of the canonical representation of
+// y.
+func (v *verifier) parity(y *emulated.Element[Fp]) frontend.Variable {
+	y = v.fp.Reduce(y)
+	v.fp.AssertIsInRange(y)
+	return v.fp.ToBits(y)[0]
+}
+
+// fpToBytes returns the 32-byte big-endian encoding of x. It asserts that x is
+// less than the modulus.
+func (v *verifier) fpToBytes(x *emulated.Element[Fp]) []uints.U8 {
+	x = v.fp.Reduce(x)
+	v.fp.AssertIsInRange(x)
+	return v.bitsToBytes(v.fp.ToBits(x)[:256])
+}
+
+// frToBytes returns the 32-byte big-endian encoding of s. It asserts that s is
+// less than the modulus.
+func (v *verifier) frToBytes(s *emulated.Element[Fr]) []uints.U8 {
+	s = v.fr.Reduce(s)
+	v.fr.AssertIsInRange(s)
+	return v.bitsToBytes(v.fr.ToBits(s)[:256])
+}
+
+// bitsToBytes packs the little-endian bits into big-endian bytes.
+func (v *verifier) bitsToBytes(bits []frontend.Variable) []uints.U8 {
+	res := make([]uints.U8, len(bits)/8)
+	for i := range res {
+		b := bits[8*(len(res)-1-i) : 8*(len(res)-i)]
+		res[i] = v.uapi.ByteValueOf(v.api.FromBinary(b...))
+	}
+	return res
+}
+
+// bytesToFr returns the scalar given by the big-endian bytes. The result is
+// not reduced.
+func (v *verifier) bytesToFr(bytes []uints.U8) *emulated.Element[Fr] {
+	bits := make([]frontend.Variable, 0, 8*len(bytes))
+	for i := len(bytes) - 1; i >= 0; i-- {
+		bits = append(bits, v.api.ToBinary(bytes[i].Val, 8)...)
+	}
+	return v.fr.FromBits(bits...)
+}
@@ -0,0 +1,154 @@
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+// sign returns the x-only public key and the BIP-340 signature of msg for the
+// secret key sk using a random nonce.
+func sign(t *testing.T, sk *big.Int, msg []byte) (pk []byte, sig []byte) {
+	_, g := secp256k1.Generators()
+	n := ecc.SECP256K1.ScalarField()
+	var P secp256k1.G1Affine
+	P.ScalarMultiplication(&g, sk)
+	d := new(big.Int).Set(sk)
+	if P.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		d.Sub(n, d)
+	}
+	k, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var R secp256k1.G1Affine
+	R.ScalarMultiplication(&g, k)
+	if R.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		k.Sub(n, k)
+	}
+	px, rx := P.X.Bytes(), R.X.Bytes()
+	e := new(big.Int).SetBytes(taggedHash(tagChallenge, rx[:], px[:], msg))
+	e.Mul(e, d).Add(e, k).Mod(e, n)
+	sig = append(rx[:], e.FillBytes(make([]byte, 32))...)
+	return px[:], sig
+}
+
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+func newPublicKey(pk []byte) PublicKey {
+	return PublicKey{X: emulated.ValueOf[Fp](new(big.Int).SetBytes(pk))}
+}
+
+func newSignature(sig []byte) Signature {
+	return Signature{
+		R: emulated.ValueOf[Fp](new(big.Int).SetBytes(sig[:32])),
+		S: emulated.ValueOf[Fr](new(big.Int).SetBytes(sig[32:])),
+	}
+}
+
+type verifyCircuit struct {
+	Pk  PublicKey
+	Msg []uints.U8
+	Sig Signature
+}
+
+func (c *verifyCircuit) Define(api frontend.API) error {
+	return c.Pk.Verify(api, c.Msg, &c.Sig)
+}
+
+func TestVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := rand.Int(rand.Reader, ecc.SECP256K1.ScalarField())
+	assert.NoError(err)
+	msg := []byte("testing BIP-340 Schnorr signatures")
+	pk, sig := sign(t, sk, msg)
+	circuit := verifyCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := verifyCircuit{
+		Pk:  newPublicKey(pk),
+		Msg: uints.NewU8Array(msg),
+		Sig: newSignature(sig),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong message
+	witness.Msg = uints.NewU8Array([]byte("testing BIP-340 Schnorr signaturez"))
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestVerifyTestVector(t *testing.T) {
+	// test vector 0 from BIP-340
+	assert := test.NewAssert(t)
+	pk, _ := hex.DecodeString("F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9")
+	msg, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000000")
+	sig, _ := hex.DecodeString("E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0")
+	circuit := verifyCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := verifyCircuit{
+		Pk:  newPublicKey(pk),
+		Msg: uints.NewU8Array(msg),
+		Sig: newSignature(sig),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type batchVerifyCircuit struct {
+	Pks  []PublicKey
+	Msgs [][]uints.U8
+	Sigs []Signature
+}
+
+func (c *batchVerifyCircuit) Define(api frontend.API) error {
+	return BatchVerify(api, c.Pks, c.Msgs, c.Sigs)
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbSigs = 3
+	circuit := batchVerifyCircuit{
+		Pks:  make([]PublicKey, nbSigs),
+		Msgs: make([][]uints.U8, nbSigs),
+		Sigs: make([]Signature, nbSigs),
+	}
+	witness := batchVerifyCircuit{
+		Pks:  make([]PublicKey, nbSigs),
+		Msgs: make([][]uints.U8, nbSigs),
+		Sigs: make([]Signature, nbSigs),
+	}
+	for i := 0; i < nbSigs; i++ {
+		sk, err := rand.Int(rand.Reader, ecc.SECP256K1.ScalarField())
+		assert.NoError(err)
+		msg := []byte{'m', 'e', 's', 's', 'a', 'g', 'e', ' ', byte('0' + i)}
+		pk, sig := sign(t, sk, msg)
+		circuit.Msgs[i] = make([]uints.U8, len(msg))
+		witness.Pks[i] = newPublicKey(pk)
+		witness.Msgs[i] = uints.NewU8Array(msg)
+		witness.Sigs[i] = newSignature(sig)
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// swapped signatures
+	witness.Sigs[0], witness.Sigs[1] = witness.Sigs[1], witness.Sigs[0]
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
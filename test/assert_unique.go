@@ -0,0 +1,208 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/tinyfield"
+	"github.com/consensys/gnark/frontend"
+	fr "github.com/consensys/gnark/internal/tinyfield"
+)
+
+// ErrWitnessNotUnique is returned when a second solution of a constraint
+// system is found for the same inputs.
+var ErrWitnessNotUnique = errors.New("witness is not unique")
+
+// uniqueWitnessSearchBound bounds the number of partial assignments visited
+// when searching for a second solution.
+const uniqueWitnessSearchBound = 1 << 22
+
+// CheckUniqueWitness checks that the valid assignment determines a unique
+// solution of the constraint system. It searches, by exhaustive enumeration of
+// the internal wires, for a second assignment which satisfies all the
+// constraints with the same public and secret inputs, and fails the test if one
+// is found. Such an assignment usually means that a hint output is not fully
+// constrained.
+//
+// The constraint system must be compiled over the tinyfield (see
+// [frontend.Compile] with [fr.Modulus]), and must be small enough for the search
+// to terminate. Constraint systems with commitments are not supported.
+//
+// The solver options given with [WithSolverOpts] are used to compute the
+// reference solution.
+func (assert *Assert) CheckUniqueWitness(ccs constraint.ConstraintSystem, validAssignment frontend.Circuit, opts ...TestingOption) {
+	opt := assert.options(opts...)
+
+	w, err := frontend.NewWitness(validAssignment, fr.Modulus())
+	assert.NoError(err, "can't parse valid assignment")
+
+	err = checkUniqueWitness(ccs, w, opt)
+	assert.NoError(err)
+}
+
+// checkUniqueWitness returns an error wrapping ErrWitnessNotUnique if the
+// constraint system admits a second solution for the inputs.
+func checkUniqueWitness(ccs constraint.ConstraintSystem, w witness.Witness, opt testingConfig) error {
+	system, ok := ccs.(*cs.R1CS)
+	if !ok {
+		return errors.New("witness uniqueness check requires a constraint system over the tinyfield")
+	}
+	if c := system.GetCommitments(); c != nil && len(c.CommitmentIndexes()) != 0 {
+		return errors.New("witness uniqueness check doesn't support commitments")
+	}
+
+	solution, err := system.Solve(w, opt.solverOpts...)
+	if err != nil {
+		return fmt.Errorf("valid assignment doesn't solve the constraint system: %w", err)
+	}
+
+	nbInputs := system.GetNbPublicVariables() + system.GetNbSecretVariables()
+	nbWires := nbInputs + system.GetNbInternalVariables()
+
+	// reference solution and the constraints, as predicates over the wire values.
+	values := make([]fr.Element, nbWires)
+	copy(values, w.Vector().(fr.Vector))
+	var constraints []uniqueConstraint
+	switch s := solution.(type) {
+	case *cs.R1CSSolution:
+		copy(values, s.W)
+		it := system.GetR1CIterator()
+		for r1c := it.Next(); r1c != nil; r1c = it.Next() {
+			constraints = append(constraints, newR1CPredicate(system, r1c))
+		}
+	case *cs.SparseR1CSSolution:
+		// the solution only holds the values of the wires used in the
+		// constraints, after the placeholder constraints of the public inputs.
+		offset := system.GetNbPublicVariables()
+		it := system.GetSparseR1CIterator()
+		for c := it.Next(); c != nil; c = it.Next() {
+			values[c.XA] = s.L[offset]
+			values[c.XB] = s.R[offset]
+			values[c.XC] = s.O[offset]
+			constraints = append(constraints, newSparseR1CPredicate(system, c))
+			offset++
+		}
+	default:
+		return fmt.Errorf("unexpected solution type %T", solution)
+	}
+	reference := make([]fr.Element, nbWires)
+	copy(reference, values)
+
+	// each constraint is checked once its last internal wire is assigned.
+	// Constraints over the inputs only are satisfied by the reference solution.
+	checks := make([][]uniqueConstraint, nbWires)
+	for _, c := range constraints {
+		last := -1
+		for _, wID := range c.wires {
+			if wID >= nbInputs && wID > last {
+				last = wID
+			}
+		}
+		if last != -1 {
+			checks[last] = append(checks[last], c)
+		}
+	}
+
+	q := fr.Modulus().Uint64()
+	nbVisited := 0
+	var search func(wID int, differs bool) (bool, error)
+	search = func(wID int, differs bool) (bool, error) {
+		if wID == nbWires {
+			return differs, nil
+		}
+		for v := uint64(0); v < q; v++ {
+			if nbVisited++; nbVisited > uniqueWitnessSearchBound {
+				return false, fmt.Errorf("witness uniqueness search bound (%d) reached, the circuit is too large", uniqueWitnessSearchBound)
+			}
+			values[wID].SetUint64(v)
+			satisfied := true
+			for _, c := range checks[wID] {
+				if !c.isSatisfied(values) {
+					satisfied = false
+					break
+				}
+			}
+			if !satisfied {
+				continue
+			}
+			found, err := search(wID+1, differs || !values[wID].Equal(&reference[wID]))
+			if found || err != nil {
+				return found, err
+			}
+		}
+		values[wID] = reference[wID]
+		return false, nil
+	}
+
+	found, err := search(nbInputs, false)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	var sbb strings.Builder
+	for wID := nbInputs; wID < nbWires; wID++ {
+		if !values[wID].Equal(&reference[wID]) {
+			sbb.WriteString(fmt.Sprintf("\n%s: %s instead of %s", system.VariableToString(wID), values[wID].String(), reference[wID].String()))
+		}
+	}
+	return fmt.Errorf("%w, other solution:%s", ErrWitnessNotUnique, sbb.String())
+}
+
+// uniqueConstraint is a constraint of the system seen as a predicate over the
+// wire values.
+type uniqueConstraint struct {
+	wires       []int
+	isSatisfied func(values []fr.Element) bool
+}
+
+func newR1CPredicate(system *cs.R1CS, r1c *constraint.R1C) uniqueConstraint {
+	l, r, o := r1c.L.Clone(), r1c.R.Clone(), r1c.O.Clone()
+	eval := func(le constraint.LinearExpression, values []fr.Element) fr.Element {
+		var res, tmp fr.Element
+		for _, t := range le {
+			tmp.Mul(&system.Coefficients[t.CID], &values[t.VID])
+			res.Add(&res, &tmp)
+		}
+		return res
+	}
+	var wires []int
+	for _, le := range []constraint.LinearExpression{l, r, o} {
+		for _, t := range le {
+			wires = append(wires, t.WireID())
+		}
+	}
+	return uniqueConstraint{
+		wires: wires,
+		isSatisfied: func(values []fr.Element) bool {
+			a, b, c := eval(l, values), eval(r, values), eval(o, values)
+			a.Mul(&a, &b)
+			return a.Equal(&c)
+		},
+	}
+}
+
+func newSparseR1CPredicate(system *cs.SparseR1CS, c *constraint.SparseR1C) uniqueConstraint {
+	sc := *c
+	return uniqueConstraint{
+		wires: []int{int(sc.XA), int(sc.XB), int(sc.XC)},
+		isSatisfied: func(values []fr.Element) bool {
+			var res, tmp fr.Element
+			tmp.Mul(&system.Coefficients[sc.QL], &values[sc.XA])
+			res.Add(&res, &tmp)
+			tmp.Mul(&system.Coefficients[sc.QR], &values[sc.XB])
+			res.Add(&res, &tmp)
+			tmp.Mul(&system.Coefficients[sc.QO], &values[sc.XC])
+			res.Add(&res, &tmp)
+			tmp.Mul(&values[sc.XA], &values[sc.XB]).Mul(&tmp, &system.Coefficients[sc.QM])
+			res.Add(&res, &tmp)
+			res.Add(&res, &system.Coefficients[sc.QC])
+			return res.IsZero()
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/internal/tinyfield"
+)
+
+type uniqueWitnessCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (c *uniqueWitnessCircuit) Define(api frontend.API) error {
+	d := api.Div(c.Z, c.X)
+	s := api.Select(api.IsZero(c.Y), d, api.Inverse(c.X))
+	api.AssertIsEqual(api.Mul(s, c.X), api.Select(api.IsZero(c.Y), c.Z, 1))
+	return nil
+}
+
+// sqrtHint returns the smallest square root of its input.
+func sqrtHint(q *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	for i := int64(0); i < q.Int64(); i++ {
+		v := big.NewInt(i)
+		if v.Mul(v, v).Mod(v, q).Cmp(inputs[0]) == 0 {
+			outputs[0].SetInt64(i)
+			return nil
+		}
+	}
+	return errors.New("no square root")
+}
+
+type sqrtCircuit struct {
+	X frontend.Variable `gnark:",public"`
+}
+
+func (c *sqrtCircuit) Define(api frontend.API) error {
+	res, err := api.Compiler().NewHint(sqrtHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(api.Mul(res[0], res[0]), c.X)
+	return nil
+}
+
+func TestCheckUniqueWitness(t *testing.T) {
+	builders := map[string]frontend.NewBuilder{"r1cs": r1cs.NewBuilder, "scs": scs.NewBuilder}
+	for name, builder := range builders {
+		assert := NewAssert(t)
+		assert.Run(func(assert *Assert) {
+			ccs, err := frontend.Compile(tinyfield.Modulus(), builder, &uniqueWitnessCircuit{})
+			assert.NoError(err)
+			assert.CheckUniqueWitness(ccs, &uniqueWitnessCircuit{X: 3, Y: 2, Z: 1})
+			assert.CheckUniqueWitness(ccs, &uniqueWitnessCircuit{X: 5, Y: 46, Z: 1})
+
+			ccs, err = frontend.Compile(tinyfield.Modulus(), builder, &sqrtCircuit{})
+			assert.NoError(err)
+			w, err := frontend.NewWitness(&sqrtCircuit{X: 4}, tinyfield.Modulus())
+			assert.NoError(err)
+			opt := assert.options(WithSolverOpts(solver.WithHints(sqrtHint)))
+			err = checkUniqueWitness(ccs, w, opt)
+			assert.ErrorIs(err, ErrWitnessNotUnique)
+		}, name)
+	}
+}